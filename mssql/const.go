@@ -45,4 +45,71 @@ const (
 	collationProp          = "collation"
 	databaseIdProp         = "database_id"
 	compatibilityLevelProp = "compatibility_level"
+	generatePasswordProp   = "generate_password"
+	generatedPasswordProp  = "generated_password"
+	lengthProp             = "length"
+	upperProp              = "upper"
+	lowerProp              = "lower"
+	numericProp            = "numeric"
+	specialProp            = "special"
+	keepersProp            = "keepers"
+	passwordPolicyProp     = "password_policy"
+	minLengthProp          = "min_length"
+	maxLengthProp          = "max_length"
+	minClassesProp         = "min_character_classes"
+	requiredClassesProp    = "required_character_classes"
+	forbiddenSubstrProp    = "forbidden_substrings"
+	forbidNameProp         = "forbid_principal_name"
+	dictionaryFileProp     = "dictionary_file"
+	nameProp               = "name"
+	namePatternProp        = "name_pattern"
+	typesProp              = "types"
+	principalsProp         = "principals"
+	loginsProp             = "logins"
+	isDisabledProp         = "is_disabled"
+	createDateProp         = "create_date"
+	modifyDateProp         = "modify_date"
+	onDestroyProp          = "on_destroy"
+	enabledProp            = "enabled"
+	isGroupProp            = "is_group"
+	principalNameProp      = "principal_name"
+	denyPermissionsProp    = "deny_permissions"
+	modeProp               = "mode"
+	modeAdditive           = "additive"
+	modeAuthoritative      = "authoritative"
+	includeConnectProp     = "include_connect"
+	authoritativeProp      = "authoritative"
+	usersProp              = "users"
+	remapOrphanProp        = "remap_orphan"
+	withoutLoginProp       = "without_login"
+	certificateNameProp    = "certificate_name"
+	asymmetricKeyNameProp  = "asymmetric_key_name"
+	enableContainmentProp  = "enable_containment"
+	migrateToContainedProp = "migrate_to_contained"
+	manageRolesProp        = "manage_roles"
+	cleanupStringSplitProp = "cleanup_string_split_helper"
+	ownedSchemasProp       = "owned_schemas"
+	databasesProp          = "databases"
+	namesProp              = "names"
+	allUserDatabasesProp   = "all_user_databases"
+	databaseStatesProp     = "database_states"
+	securableTypeProp      = "securable_type"
+	securableSchemaProp    = "securable_schema"
+	securableNameProp      = "securable_name"
+	columnsProp            = "columns"
+	columnPermissionsProp  = "column_permissions"
+	columnProp             = "column"
+	permissionProp         = "permission"
+	passwordWOProp         = "password_wo"
+	passwordWOVersionProp  = "password_wo_version"
+	scopeProp              = "scope"
+	membershipsProp        = "memberships"
+	memberNameProp         = "member_name"
+	memberTypeProp         = "member_type"
+	depthProp              = "depth"
+	pathProp               = "path"
+
+	onDestroyTransferOwnershipToProp = "on_destroy_transfer_ownership_to"
+	onDestroyFailOnOwnedProp         = "on_destroy_fail_on_owned_objects"
+	withGrantOptionPermissionsProp   = "with_grant_option_permissions"
 )
@@ -0,0 +1,166 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+const (
+	databaseStateInSync      = "in_sync"
+	databaseStateDrifted     = "drifted"
+	databaseStateMissing     = "missing"
+	databaseStateUnavailable = "unavailable"
+)
+
+type DatabasesConnector interface {
+	ListDatabases(ctx context.Context, pattern string, includeSystem bool) ([]string, error)
+}
+
+var databasesSelectorKeys = []string{
+	databasesProp + ".0." + namesProp,
+	databasesProp + ".0." + namePatternProp,
+	databasesProp + ".0." + allUserDatabasesProp,
+}
+
+func getDatabasesSelectorSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				namesProp: {
+					Type:         schema.TypeSet,
+					Optional:     true,
+					MinItems:     1,
+					ExactlyOneOf: databasesSelectorKeys,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				namePatternProp: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ExactlyOneOf: databasesSelectorKeys,
+				},
+				allUserDatabasesProp: {
+					Type:         schema.TypeBool,
+					Optional:     true,
+					ExactlyOneOf: databasesSelectorKeys,
+				},
+			},
+		},
+	}
+}
+
+func getDatabaseStatesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// resolveDatabases returns the accessible online databases selected by the databases block, and the explicitly listed
+// databases that are not available.
+func resolveDatabases(ctx context.Context, data *schema.ResourceData, connector DatabasesConnector) ([]string, []string, error) {
+	if pattern := data.Get(databasesProp + ".0." + namePatternProp).(string); pattern != "" {
+		databases, err := connector.ListDatabases(ctx, pattern, false)
+		return databases, nil, err
+	}
+	if data.Get(databasesProp + ".0." + allUserDatabasesProp).(bool) {
+		databases, err := connector.ListDatabases(ctx, "", false)
+		return databases, nil, err
+	}
+
+	available, err := connector.ListDatabases(ctx, "", true)
+	if err != nil {
+		return nil, nil, err
+	}
+	availableSet := make(map[string]string, len(available))
+	for _, database := range available {
+		availableSet[strings.ToLower(database)] = database
+	}
+	var databases, unavailable []string
+	for _, name := range toStringSlice(data.Get(databasesProp + ".0." + namesProp).(*schema.Set).List()) {
+		if database, ok := availableSet[strings.ToLower(name)]; ok {
+			databases = append(databases, database)
+		} else {
+			unavailable = append(unavailable, name)
+		}
+	}
+	return databases, unavailable, nil
+}
+
+// resolveDatabasesForApply is resolveDatabases for create and update, which fail if a listed database is not available.
+func resolveDatabasesForApply(ctx context.Context, data *schema.ResourceData, connector DatabasesConnector) ([]string, error) {
+	databases, unavailable, err := resolveDatabases(ctx, data, connector)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to resolve databases")
+	}
+	if len(unavailable) > 0 {
+		return nil, errors.Errorf("databases [%s] do not exist or are not accessible", strings.Join(unavailable, "], ["))
+	}
+	return databases, nil
+}
+
+// stateDatabases returns the databases recorded in the previous database_states that still exist.
+func stateDatabases(ctx context.Context, data *schema.ResourceData, connector DatabasesConnector) ([]string, error) {
+	oldStates, _ := data.GetChange(databaseStatesProp)
+	states := oldStates.(map[string]interface{})
+	if len(states) == 0 {
+		return nil, nil
+	}
+	available, err := connector.ListDatabases(ctx, "", true)
+	if err != nil {
+		return nil, err
+	}
+	var databases []string
+	for _, database := range available {
+		if _, ok := states[database]; ok {
+			databases = append(databases, database)
+		}
+	}
+	return databases, nil
+}
+
+// removedDatabases returns the databases of the previous database_states that are no longer selected but still exist.
+func removedDatabases(ctx context.Context, data *schema.ResourceData, connector DatabasesConnector, selected []string) ([]string, error) {
+	databases, err := stateDatabases(ctx, data, connector)
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for _, database := range databases {
+		if !containsString(selected, database) {
+			removed = append(removed, database)
+		}
+	}
+	return removed, nil
+}
+
+// setDatabaseStates stores the state of every selected database, and marks the unavailable ones.
+func setDatabaseStates(data *schema.ResourceData, states map[string]string, unavailable []string) error {
+	for _, database := range unavailable {
+		states[database] = databaseStateUnavailable
+	}
+	return data.Set(databaseStatesProp, states)
+}
+
+// customizeDiffDatabaseStates plans an update when the last refresh found a database that is not in sync.
+func customizeDiffDatabaseStates(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	for _, state := range diff.Get(databaseStatesProp).(map[string]interface{}) {
+		if state != databaseStateInSync {
+			return diff.SetNewComputed(databaseStatesProp)
+		}
+	}
+	return nil
+}
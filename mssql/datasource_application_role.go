@@ -0,0 +1,79 @@
+package mssql
+
+import (
+	"context"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func dataSourceApplicationRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceApplicationRoleRead,
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  defaultDatabaseDefault,
+			},
+			roleNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			defaultSchemaProp: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			principalIdProp: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+func dataSourceApplicationRoleRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "applicationrole", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	role, err := connector.GetApplicationRole(ctx, database, roleName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to get application role [%s].[%s]", database, roleName))
+	}
+
+	if role == nil {
+		return diag.Errorf("The application role [%s] in database [%s] does not exist", roleName, database)
+	} else {
+		if err = data.Set(principalIdProp, role.PrincipalID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(defaultSchemaProp, role.DefaultSchema); err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(getApplicationRoleID(data))
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataApplicationRole_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckApplicationRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataApplicationRole(t, "data_app_role", "login"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_application_role.data_app_role", "id", "sqlserver://localhost:1433/master/application_role/test_data_app_role"),
+					resource.TestCheckResourceAttr("data.mssql_application_role.data_app_role", "default_schema", "guest"),
+					resource.TestCheckResourceAttrPair("data.mssql_application_role.data_app_role", "principal_id", "mssql_application_role.data_app_role", "principal_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataApplicationRole(t *testing.T, name string, login string) string {
+	data := map[string]interface{}{"role_name": "test_data_app_role", "password": "valueIsH8kd$¡", "default_schema": "guest"}
+	config := testAccCheckApplicationRole(t, name, login, data)
+	text := `data "mssql_application_role" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name = mssql_application_role.{{ .name }}.role_name
+			}`
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return config + "\n" + res
+}
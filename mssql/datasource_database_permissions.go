@@ -44,6 +44,20 @@ func dataSourceDatabasePermissions() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			withGrantOptionPermissionsProp: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			denyPermissionsProp: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Read: defaultTimeout,
@@ -82,6 +96,12 @@ func dataSourceDatabasePermissionsRead(ctx context.Context, data *schema.Resourc
 		if err = data.Set(permissionsProp, permissions.Permissions); err != nil {
 			return diag.FromErr(err)
 		}
+		if err = data.Set(withGrantOptionPermissionsProp, permissions.WithGrantOptionPermissions); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(denyPermissionsProp, permissions.DenyPermissions); err != nil {
+			return diag.FromErr(err)
+		}
 		data.SetId(getDatabasePermissionsID(data))
 	}
 
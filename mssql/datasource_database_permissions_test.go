@@ -38,6 +38,27 @@ func TestAccDataDatabasePermissions_Local_Basic(t *testing.T) {
 	})
 }
 
+func TestAccDataDatabasePermissions_Local_GrantOptionAndDeny(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDataDatabasePermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataDataBasepermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_states", "with_grant_option_permissions": "[\"EXECUTE\"]", "deny_permissions": "[\"DELETE\"]", "login_name": "db_login_perm_states", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_database_permissions.database", "permissions.#", "0"),
+					resource.TestCheckResourceAttr("data.mssql_database_permissions.database", "with_grant_option_permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.mssql_database_permissions.database", "with_grant_option_permissions.0", "EXECUTE"),
+					resource.TestCheckResourceAttr("data.mssql_database_permissions.database", "deny_permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.mssql_database_permissions.database", "deny_permissions.0", "DELETE"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDataDatabasePermissions_Azure_Basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -101,7 +122,9 @@ func testAccCheckDataDataBasepermissions(t *testing.T, name string, login string
 				}
 				database     = "{{ .database }}"
 				username = mssql_user.{{ .name }}.username
-				permissions  = {{ .permissions }}
+				{{ with .permissions }}permissions = {{ . }}{{ end }}
+				{{ with .with_grant_option_permissions }}with_grant_option_permissions = {{ . }}{{ end }}
+				{{ with .deny_permissions }}deny_permissions = {{ . }}{{ end }}
 			}
 			data "mssql_database_permissions" "{{ .name }}" {
 				server {
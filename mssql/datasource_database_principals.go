@@ -0,0 +1,196 @@
+package mssql
+
+import (
+	"context"
+	"time"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+var (
+	databaseUserTypes      = []string{"SQL_USER", "WINDOWS_USER", "WINDOWS_GROUP", "CERTIFICATE_MAPPED_USER", "ASYMMETRIC_KEY_MAPPED_USER", "EXTERNAL_USER", "EXTERNAL_GROUPS"}
+	databasePrincipalTypes = append([]string{"DATABASE_ROLE", "APPLICATION_ROLE"}, databaseUserTypes...)
+)
+
+func dataSourceDatabasePrincipals() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDatabasePrincipalsRead,
+		Schema:      getDatabasePrincipalsSchema(principalsProp, databasePrincipalTypes),
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+type DatabasePrincipalsConnector interface {
+	GetDatabasePrincipals(ctx context.Context, database, namePattern, roleName string) ([]model.DatabasePrincipal, error)
+}
+
+func getDatabasePrincipalsSchema(listProp string, types []string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		serverProp: {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Required: true,
+			Elem: &schema.Resource{
+				Schema: getServerSchema(serverProp),
+			},
+		},
+		databaseProp: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  defaultDatabaseDefault,
+		},
+		namePatternProp: {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		typesProp: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(types, false),
+			},
+		},
+		roleNameProp: {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		listProp: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					nameProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					principalIdProp: {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					typeStrProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					authenticationTypeProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					sidStrProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					loginNameProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					defaultSchemaProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					defaultLanguageProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					createDateProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					modifyDateProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					rolesProp: {
+						Type:     schema.TypeSet,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					ownedSchemasProp: {
+						Type:     schema.TypeSet,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatabasePrincipalsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "database_principals", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	return readDatabasePrincipals(ctx, data, meta, principalsProp, databasePrincipalTypes)
+}
+
+// readDatabasePrincipals sets listProp to the database principals matching the filters. Only principals with a type in
+// allowedTypes are returned if the types filter is not set.
+func readDatabasePrincipals(ctx context.Context, data *schema.ResourceData, meta interface{}, listProp string, allowedTypes []string) diag.Diagnostics {
+	database := data.Get(databaseProp).(string)
+	namePattern := data.Get(namePatternProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	types := toStringSlice(data.Get(typesProp).(*schema.Set).List())
+	if len(types) == 0 {
+		types = allowedTypes
+	}
+	typeSet := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		typeSet[t] = struct{}{}
+	}
+
+	connector, err := getDatabasePrincipalsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	principals, err := connector.GetDatabasePrincipals(ctx, database, namePattern, roleName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read principals of database [%s]", database))
+	}
+
+	result := make([]map[string]interface{}, 0, len(principals))
+	for _, principal := range principals {
+		if _, ok := typeSet[principal.TypeDesc]; !ok {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			nameProp:               principal.Name,
+			principalIdProp:        principal.PrincipalID,
+			typeStrProp:            principal.TypeDesc,
+			authenticationTypeProp: principal.AuthType,
+			sidStrProp:             principal.SIDStr,
+			loginNameProp:          principal.LoginName,
+			defaultSchemaProp:      principal.DefaultSchema,
+			defaultLanguageProp:    principal.DefaultLanguage,
+			createDateProp:         principal.CreateDate.UTC().Format(time.RFC3339),
+			modifyDateProp:         principal.ModifyDate.UTC().Format(time.RFC3339),
+			rolesProp:              principal.Roles,
+			ownedSchemasProp:       principal.OwnedSchemas,
+		})
+	}
+	if err = data.Set(listProp, result); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(getDatabasePrincipalsID(data, listProp))
+
+	return nil
+}
+
+func getDatabasePrincipalsConnector(meta interface{}, data *schema.ResourceData) (DatabasePrincipalsConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(DatabasePrincipalsConnector), nil
+}
@@ -45,6 +45,7 @@ func dataSourceDatabaseRole() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			permissionsProp: getDatabaseRolePermissionsSchema(true),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Read: defaultTimeout,
@@ -84,6 +85,13 @@ func dataSourceDatabaseRoleRead(ctx context.Context, data *schema.ResourceData,
 		if err = data.Set(ownerIdProp, role.OwnerId); err != nil {
 			return diag.FromErr(err)
 		}
+		permissions, err := connector.GetDatabaseRolePermissions(ctx, database, roleName)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to get permissions of role [%s].[%s]", database, roleName))
+		}
+		if err = data.Set(permissionsProp, flattenRolePermissions(permissions, nil)); err != nil {
+			return diag.FromErr(err)
+		}
 		data.SetId(getDatabaseRoleID(data))
 	}
 
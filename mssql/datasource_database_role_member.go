@@ -0,0 +1,71 @@
+package mssql
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func dataSourceDatabaseRoleMember() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDatabaseRoleMemberRead,
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  defaultDatabaseDefault,
+			},
+			roleNameProp: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			membersProp: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+func dataSourceDatabaseRoleMemberRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "database_role_member", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	members, err := connector.GetDatabaseRoleMember(ctx, database, roleName, nil)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read role members for role [%s].[%s]", database, roleName))
+	}
+	if members == nil {
+		return diag.Errorf("No role found for [%s].[%s]", database, roleName)
+	}
+	if err = data.Set(membersProp, members.Members); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(getDatabaseRoleMemberID(data))
+
+	return nil
+}
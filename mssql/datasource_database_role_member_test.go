@@ -0,0 +1,61 @@
+package mssql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDatabaseRoleMember_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDataSourceDatabaseRoleMember(t, "local_basic", "login", map[string]interface{}{"database": "master", "role_name": "db_owner"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "id", "sqlserver://localhost:1433/master/role_member/db_owner"),
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "database", "master"),
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "role_name", "db_owner"),
+					resource.TestCheckTypeSetElemAttr("data.mssql_database_role_member.local_basic", "members.*", "dbo"),
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "server.#", "1"),
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "server.0.host", "localhost"),
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "server.0.login.#", "1"),
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "server.0.login.0.username", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
+					resource.TestCheckResourceAttr("data.mssql_database_role_member.local_basic", "server.0.azure_login.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceDatabaseRoleMember(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `data "mssql_database_role_member" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				database  = "{{ .database }}"
+				role_name = "{{ .role_name }}"
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
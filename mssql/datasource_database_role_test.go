@@ -30,6 +30,7 @@ func TestAccDataDatabaseRole_Local_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("data.mssql_database_role.data_local_test", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
 					resource.TestCheckResourceAttr("data.mssql_database_role.data_local_test", "server.0.azure_login.#", "0"),
 					resource.TestCheckResourceAttrSet("data.mssql_database_role.data_local_test", "principal_id"),
+					resource.TestCheckResourceAttr("data.mssql_database_role.data_local_test", "permissions.#", "0"),
 				),
 			},
 		},
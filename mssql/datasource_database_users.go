@@ -0,0 +1,25 @@
+package mssql
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDatabaseUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDatabaseUsersRead,
+		Schema:      getDatabasePrincipalsSchema(usersProp, databaseUserTypes),
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+func dataSourceDatabaseUsersRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "database_users", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	return readDatabasePrincipals(ctx, data, meta, usersProp, databaseUserTypes)
+}
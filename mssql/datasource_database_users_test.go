@@ -0,0 +1,87 @@
+package mssql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataDatabaseUsers_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataDatabaseUsers(t, "basic", "login", map[string]interface{}{"username": "dbusers_basic", "name_pattern": "dbusers[_]%"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_database_users.basic", "id", "sqlserver://localhost:1433/master/users"),
+					resource.TestCheckResourceAttr("data.mssql_database_users.basic", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.mssql_database_users.basic", "users.0.name", "dbusers_basic"),
+					resource.TestCheckResourceAttr("data.mssql_database_users.basic", "users.0.type", "SQL_USER"),
+					resource.TestCheckResourceAttr("data.mssql_database_users.basic", "users.0.authentication_type", "INSTANCE"),
+					resource.TestCheckResourceAttr("data.mssql_database_users.basic", "users.0.login_name", "dbusers_basic"),
+					resource.TestCheckResourceAttr("data.mssql_database_users.basic", "users.0.default_schema", "dbo"),
+					resource.TestCheckResourceAttr("data.mssql_database_users.basic", "users.0.roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.mssql_database_users.basic", "users.0.roles.*", "db_datareader"),
+					resource.TestCheckResourceAttrPair("data.mssql_database_users.basic", "users.0.sid", "mssql_user.basic", "sid"),
+					resource.TestCheckResourceAttrPair("data.mssql_database_users.basic", "users.0.principal_id", "mssql_user.basic", "principal_id"),
+				),
+			},
+			{
+				Config: testAccDataDatabaseUsers(t, "basic", "login", map[string]interface{}{"username": "dbusers_basic", "name_pattern": "dbusers[_]%", "role_name": "db_datawriter"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_database_users.basic", "users.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataDatabaseUsers(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_login" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				login_name = "{{ .username }}"
+				password   = "valueIsH8kd$¡"
+			}
+			resource "mssql_user" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				username   = "{{ .username }}"
+				login_name = mssql_login.{{ .name }}.login_name
+				roles      = ["db_datareader"]
+			}
+			data "mssql_database_users" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .name_pattern }}name_pattern = "{{ . }}"{{ end }}
+				{{ with .role_name }}role_name = "{{ . }}"{{ end }}
+				depends_on = [mssql_user.{{ .name }}]
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
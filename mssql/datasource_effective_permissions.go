@@ -0,0 +1,169 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+// effectivePermissionClasses are the securable classes of mssql_effective_permissions, passed to sys.fn_my_permissions.
+var effectivePermissionClasses = append([]string{"SERVER", "DATABASE"}, securableTypes...)
+
+func dataSourceEffectivePermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEffectivePermissionsRead,
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  defaultDatabaseDefault,
+			},
+			usernameProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{usernameProp, loginNameProp},
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			loginNameProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{usernameProp, loginNameProp},
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			securableTypeProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DATABASE",
+				ValidateFunc: validation.StringInSlice(effectivePermissionClasses, false),
+			},
+			securableSchemaProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			securableNameProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			permissionsProp: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			columnPermissionsProp: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						columnProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						permissionProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+type EffectivePermissionsConnector interface {
+	GetEffectivePermissions(ctx context.Context, database, principalName string, isLogin bool, securableClass, securableSchema, securableName string) ([]model.EffectivePermission, error)
+}
+
+func dataSourceEffectivePermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "effective_permissions", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	principalName := data.Get(usernameProp).(string)
+	loginName, isLogin := data.GetOk(loginNameProp)
+	if isLogin {
+		principalName = loginName.(string)
+	}
+	securableType := data.Get(securableTypeProp).(string)
+	securableSchema := data.Get(securableSchemaProp).(string)
+	securableName := data.Get(securableNameProp).(string)
+
+	switch {
+	case securableType == "SERVER" || securableType == "DATABASE":
+		if securableSchema != "" || securableName != "" {
+			return diag.Errorf("%s and %s cannot be set for securable type %s", securableSchemaProp, securableNameProp, securableType)
+		}
+	case securableName == "":
+		return diag.Errorf("%s is required for securable type %s", securableNameProp, securableType)
+	case isSchemaScopedSecurable(securableType):
+		if securableSchema == "" {
+			securableSchema = "dbo"
+		}
+	case securableSchema != "":
+		return diag.Errorf("%s cannot be set for securable type %s", securableSchemaProp, securableType)
+	}
+
+	connector, err := getEffectivePermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissions, err := connector.GetEffectivePermissions(ctx, database, principalName, isLogin, securableType, securableSchema, securableName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read effective permissions of [%s] on %s in database [%s]", principalName, strings.TrimSpace(securableType+" "+securableName), database))
+	}
+
+	securablePermissions := make([]string, 0, len(permissions))
+	columnPermissions := make([]map[string]interface{}, 0)
+	for _, permission := range permissions {
+		if permission.Column == "" {
+			securablePermissions = append(securablePermissions, permission.Permission)
+		} else {
+			columnPermissions = append(columnPermissions, map[string]interface{}{
+				columnProp:     permission.Column,
+				permissionProp: permission.Permission,
+			})
+		}
+	}
+
+	if err = data.Set(securableSchemaProp, securableSchema); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = data.Set(permissionsProp, securablePermissions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = data.Set(columnPermissionsProp, columnPermissions); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(getEffectivePermissionsID(data))
+
+	return nil
+}
+
+func getEffectivePermissionsConnector(meta interface{}, data *schema.ResourceData) (EffectivePermissionsConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(EffectivePermissionsConnector), nil
+}
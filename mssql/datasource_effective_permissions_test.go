@@ -0,0 +1,77 @@
+package mssql
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataEffectivePermissions_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabasePermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataEffectivePermissions(t, "data_effective", "login"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_effective_permissions.data_effective", "id", "sqlserver://localhost:1433/master/effective_permissions/user/db_user_effective/DATABASE"),
+					// Granted directly, inherited from db_datareader and granted on creation
+					resource.TestCheckTypeSetElemAttr("data.mssql_effective_permissions.data_effective", "permissions.*", "EXECUTE"),
+					resource.TestCheckTypeSetElemAttr("data.mssql_effective_permissions.data_effective", "permissions.*", "SELECT"),
+					resource.TestCheckTypeSetElemAttr("data.mssql_effective_permissions.data_effective", "permissions.*", "CONNECT"),
+					resource.TestCheckResourceAttr("data.mssql_effective_permissions.data_effective_server", "id", "sqlserver://localhost:1433/master/effective_permissions/login/db_login_effective/SERVER"),
+					resource.TestCheckTypeSetElemAttr("data.mssql_effective_permissions.data_effective_server", "permissions.*", "CONNECT SQL"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataEffectivePermissions_Local_InvalidPrincipal(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "mssql_effective_permissions" "invalid" {
+							server {
+								host = "localhost"
+								login {}
+							}
+							username = "db_user'; REVERT; --"
+						}`,
+				ExpectError: regexp.MustCompile("invalid SQL identifier"),
+			},
+		},
+	})
+}
+
+func testAccDataEffectivePermissions(t *testing.T, name string, login string) string {
+	data := map[string]interface{}{"database": "master", "username": "db_user_effective", "permissions": `["EXECUTE"]`, "login_name": "db_login_effective", "login_password": "valueIsH8kd$¡", "roles": `["db_datareader"]`}
+	config := testAccCheckDatabasePermissions(t, name, login, data)
+	text := `data "mssql_effective_permissions" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				username = mssql_database_permissions.{{ .name }}.username
+			}
+			data "mssql_effective_permissions" "{{ .name }}_server" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				login_name     = mssql_login.{{ .name }}.login_name
+				securable_type = "SERVER"
+			}`
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return config + "\n" + res
+}
@@ -41,6 +41,14 @@ func dataSourceEntraIDLogin() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			enabledProp: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			isGroupProp: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Read: defaultTimeout,
@@ -78,6 +86,12 @@ func dataSourceEntraIDLoginRead(ctx context.Context, data *schema.ResourceData,
 		if err = data.Set(defaultLanguageProp, EntraIDLogin.DefaultLanguage); err != nil {
 			return diag.FromErr(err)
 		}
+		if err = data.Set(enabledProp, !EntraIDLogin.IsDisabled); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(isGroupProp, EntraIDLogin.IsGroup); err != nil {
+			return diag.FromErr(err)
+		}
 		data.SetId(getLoginID(data))
 	}
 
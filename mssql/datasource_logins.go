@@ -0,0 +1,25 @@
+package mssql
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceLogins() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLoginsRead,
+		Schema:      getServerPrincipalsSchema(loginsProp, serverLoginTypes),
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+func dataSourceLoginsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "logins", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	return readServerPrincipals(ctx, data, meta, loginsProp, serverLoginTypes)
+}
@@ -0,0 +1,92 @@
+package mssql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataLogins_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccDataLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataLogins(t, "basic", "login", map[string]interface{}{"login_name": "logins_basic", "password": "valueIsH8kd$¡", "name_pattern": "logins[_]%"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_logins.basic", "id", "sqlserver://localhost:1433/logins"),
+					resource.TestCheckResourceAttr("data.mssql_logins.basic", "logins.#", "1"),
+					resource.TestCheckResourceAttr("data.mssql_logins.basic", "logins.0.name", "logins_basic"),
+					resource.TestCheckResourceAttr("data.mssql_logins.basic", "logins.0.type", "SQL_LOGIN"),
+					resource.TestCheckResourceAttr("data.mssql_logins.basic", "logins.0.default_database", "master"),
+					resource.TestCheckResourceAttr("data.mssql_logins.basic", "logins.0.is_disabled", "false"),
+					resource.TestCheckResourceAttr("data.mssql_logins.basic", "logins.0.roles.#", "0"),
+					resource.TestCheckResourceAttrPair("data.mssql_logins.basic", "logins.0.sid", "mssql_login.basic", "sid"),
+					resource.TestCheckResourceAttrPair("data.mssql_logins.basic", "logins.0.principal_id", "mssql_login.basic", "principal_id"),
+					resource.TestCheckResourceAttrSet("data.mssql_logins.basic", "logins.0.create_date"),
+					resource.TestCheckResourceAttrSet("data.mssql_logins.basic", "logins.0.modify_date"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataLogins_Local_Role(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccDataLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataLogins(t, "role", "login", map[string]interface{}{"login_name": "logins_role", "password": "valueIsH8kd$¡", "role_name": "sysadmin", "types": `["SQL_LOGIN"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_logins.role", "logins.#", "1"),
+					resource.TestCheckResourceAttr("data.mssql_logins.role", "logins.0.name", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckTypeSetElemAttr("data.mssql_logins.role", "logins.0.roles.*", "sysadmin"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataLogins(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_login" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				login_name = "{{ .login_name }}"
+				password   = "{{ .password }}"
+			}
+			data "mssql_logins" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .name_pattern }}name_pattern = "{{ . }}"{{ end }}
+				{{ with .role_name }}role_name = "{{ . }}"{{ end }}
+				{{ with .types }}types = {{ . }}{{ end }}
+				depends_on = [mssql_login.{{ .name }}]
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
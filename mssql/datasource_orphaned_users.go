@@ -0,0 +1,114 @@
+package mssql
+
+import (
+	"context"
+	"time"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func dataSourceOrphanedUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOrphanedUsersRead,
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			usersProp: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						databaseProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						usernameProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						principalIdProp: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						typeStrProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						sidStrProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						createDateProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+type OrphanedUsersConnector interface {
+	GetOrphanedUsers(ctx context.Context, database string) ([]model.OrphanedUser, error)
+}
+
+func dataSourceOrphanedUsersRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "orphaned_users", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+
+	connector, err := getOrphanedUsersConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	users, err := connector.GetOrphanedUsers(ctx, database)
+	if err != nil {
+		return diag.FromErr(errors.Wrap(err, "unable to read orphaned users"))
+	}
+
+	result := make([]map[string]interface{}, 0, len(users))
+	for _, user := range users {
+		result = append(result, map[string]interface{}{
+			databaseProp:    user.Database,
+			usernameProp:    user.Username,
+			principalIdProp: user.PrincipalID,
+			typeStrProp:     user.TypeDesc,
+			sidStrProp:      user.SIDStr,
+			createDateProp:  user.CreateDate.UTC().Format(time.RFC3339),
+		})
+	}
+	if err = data.Set(usersProp, result); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(getOrphanedUsersID(data))
+
+	return nil
+}
+
+func getOrphanedUsersConnector(meta interface{}, data *schema.ResourceData) (OrphanedUsersConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(OrphanedUsersConnector), nil
+}
@@ -0,0 +1,93 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataOrphanedUsers_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccDropOrphanedUser("master", "test_orphaned_users") },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testAccCreateOrphanedUser(t, "master", "test_orphaned_users") },
+				Config:    testAccDataOrphanedUsers(t, "master", "login", map[string]interface{}{"database": "master"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_orphaned_users.master", "id", "sqlserver://localhost:1433/master/orphaned_users"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.mssql_orphaned_users.master", "users.*", map[string]string{
+						"database": "master",
+						"username": "test_orphaned_users",
+						"type":     "SQL_USER",
+					}),
+				),
+			},
+			{
+				Config: testAccDataOrphanedUsers(t, "all", "login", map[string]interface{}{}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_orphaned_users.all", "id", "sqlserver://localhost:1433/orphaned_users"),
+					resource.TestCheckResourceAttrSet("data.mssql_orphaned_users.all", "users.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataOrphanedUsers(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `data "mssql_orphaned_users" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .database }}database = "{{ . }}"{{ end }}
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccExecuteLocalScript(database, script string) error {
+	connector, err := getTestConnector(map[string]string{
+		serverProp + ".0.host":             "localhost",
+		serverProp + ".0.port":             "1433",
+		serverProp + ".0.login.0.username": os.Getenv("MSSQL_USERNAME"),
+		serverProp + ".0.login.0.password": os.Getenv("MSSQL_PASSWORD"),
+	})
+	if err != nil {
+		return err
+	}
+	return connector.DataBaseExecuteScript(database, script)
+}
+
+// testAccCreateOrphanedUser creates a user for a temporary login and drops the login, leaving the user orphaned.
+func testAccCreateOrphanedUser(t *testing.T, database, username string) {
+	script := fmt.Sprintf(`CREATE LOGIN [%[1]s_tmp] WITH PASSWORD = 'valueIsH8kd$¡';
+		CREATE USER [%[1]s] FOR LOGIN [%[1]s_tmp];
+		DROP LOGIN [%[1]s_tmp];`, username)
+	if err := testAccExecuteLocalScript(database, script); err != nil {
+		t.Fatalf("unable to create orphaned user: %s", err)
+	}
+}
+
+func testAccDropOrphanedUser(database, username string) error {
+	return testAccExecuteLocalScript(database, fmt.Sprintf("DROP USER IF EXISTS [%s]", username))
+}
@@ -0,0 +1,208 @@
+package mssql
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+func dataSourceRoleMembershipGraph() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRoleMembershipGraphRead,
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			scopeProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DATABASE",
+				ValidateFunc: validation.StringInSlice([]string{"DATABASE", "SERVER"}, false),
+			},
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  defaultDatabaseDefault,
+			},
+			roleNameProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			membershipsProp: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						roleNameProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						memberNameProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						memberTypeProp: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						depthProp: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						pathProp: {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+type RoleMembershipGraphConnector interface {
+	GetDatabaseRoleMemberships(ctx context.Context, database string) ([]model.RoleMembership, error)
+	GetServerRoleMemberships(ctx context.Context) ([]model.RoleMembership, error)
+}
+
+func dataSourceRoleMembershipGraphRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "role_membership_graph", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	scope := data.Get(scopeProp).(string)
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getRoleMembershipGraphConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var memberships []model.RoleMembership
+	if scope == "SERVER" {
+		memberships, err = connector.GetServerRoleMemberships(ctx)
+		if err != nil {
+			return diag.FromErr(errors.Wrap(err, "unable to read server role memberships"))
+		}
+	} else {
+		memberships, err = connector.GetDatabaseRoleMemberships(ctx, database)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read role memberships of database [%s]", database))
+		}
+	}
+
+	closure := roleMembershipClosure(memberships, roleName)
+	result := make([]map[string]interface{}, 0, len(closure))
+	for _, membership := range closure {
+		result = append(result, map[string]interface{}{
+			roleNameProp:   membership.roleName,
+			memberNameProp: membership.memberName,
+			memberTypeProp: membership.memberType,
+			depthProp:      len(membership.path) - 1,
+			pathProp:       membership.path,
+		})
+	}
+
+	if err = data.Set(membershipsProp, result); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(getRoleMembershipGraphID(data))
+
+	return nil
+}
+
+// roleMembershipPath is a direct or indirect membership of a principal in a role. The path runs from the role down
+// to the member, through the nested roles in between.
+type roleMembershipPath struct {
+	roleName   string
+	memberName string
+	memberType string
+	path       []string
+}
+
+// roleMembershipClosure returns the transitive closure of the direct memberships for roleName, or for every role if
+// roleName is empty. Each member is reported once per role, with the shortest path of nesting, ordered by role,
+// depth and member name.
+func roleMembershipClosure(memberships []model.RoleMembership, roleName string) []roleMembershipPath {
+	membersOf := make(map[string][]model.RoleMembership)
+	for _, membership := range memberships {
+		membersOf[membership.RoleName] = append(membersOf[membership.RoleName], membership)
+	}
+
+	// Role names are compared with the catalog collation, so the role is looked up case-insensitively and reported
+	// with its actual name
+	roles := []string{roleName}
+	for role := range membersOf {
+		if strings.EqualFold(role, roleName) {
+			roles = []string{role}
+			break
+		}
+	}
+	if roleName == "" {
+		roles = make([]string, 0, len(membersOf))
+		for role := range membersOf {
+			roles = append(roles, role)
+		}
+	}
+
+	result := make([]roleMembershipPath, 0)
+	for _, role := range roles {
+		visited := map[string]struct{}{role: {}}
+		queue := [][]string{{role}}
+		for len(queue) > 0 {
+			path := queue[0]
+			queue = queue[1:]
+			for _, membership := range membersOf[path[len(path)-1]] {
+				if _, ok := visited[membership.MemberName]; ok {
+					continue
+				}
+				visited[membership.MemberName] = struct{}{}
+				memberPath := append(append(make([]string, 0, len(path)+1), path...), membership.MemberName)
+				result = append(result, roleMembershipPath{
+					roleName:   role,
+					memberName: membership.MemberName,
+					memberType: membership.MemberType,
+					path:       memberPath,
+				})
+				queue = append(queue, memberPath)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].roleName != result[j].roleName {
+			return result[i].roleName < result[j].roleName
+		}
+		if len(result[i].path) != len(result[j].path) {
+			return len(result[i].path) < len(result[j].path)
+		}
+		return result[i].memberName < result[j].memberName
+	})
+	return result
+}
+
+func getRoleMembershipGraphConnector(meta interface{}, data *schema.ResourceData) (RoleMembershipGraphConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(RoleMembershipGraphConnector), nil
+}
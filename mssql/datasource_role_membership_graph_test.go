@@ -0,0 +1,124 @@
+package mssql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataRoleMembershipGraph_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataRoleMembershipGraph(t, "data_graph", "login"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_role_membership_graph.data_graph", "id", "sqlserver://localhost:1433/master/role_membership_graph/db_datareader"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.mssql_role_membership_graph.data_graph", "memberships.*", map[string]string{
+						"role_name":   "db_datareader",
+						"member_name": "graph_role",
+						"member_type": "DATABASE_ROLE",
+						"depth":       "1",
+					}),
+					// Member of db_datareader through graph_role
+					resource.TestCheckTypeSetElemNestedAttrs("data.mssql_role_membership_graph.data_graph", "memberships.*", map[string]string{
+						"role_name":   "db_datareader",
+						"member_name": "graph_user",
+						"member_type": "SQL_USER",
+						"depth":       "2",
+						"path.#":      "3",
+						"path.0":      "db_datareader",
+						"path.1":      "graph_role",
+						"path.2":      "graph_user",
+					}),
+					resource.TestCheckResourceAttr("data.mssql_role_membership_graph.data_graph_server", "id", "sqlserver://localhost:1433/role_membership_graph/sysadmin"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.mssql_role_membership_graph.data_graph_server", "memberships.*", map[string]string{
+						"role_name":   "sysadmin",
+						"member_name": "sa",
+						"depth":       "1",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestRoleMembershipClosure(t *testing.T) {
+	memberships := []model.RoleMembership{
+		{RoleName: "db_owner", MemberName: "admins", MemberType: "DATABASE_ROLE"},
+		{RoleName: "admins", MemberName: "alice", MemberType: "SQL_USER"},
+		{RoleName: "admins", MemberName: "ops", MemberType: "DATABASE_ROLE"},
+		{RoleName: "ops", MemberName: "alice", MemberType: "SQL_USER"},
+		{RoleName: "ops", MemberName: "bob", MemberType: "SQL_USER"},
+	}
+
+	expected := []roleMembershipPath{
+		{"db_owner", "admins", "DATABASE_ROLE", []string{"db_owner", "admins"}},
+		{"db_owner", "alice", "SQL_USER", []string{"db_owner", "admins", "alice"}},
+		{"db_owner", "ops", "DATABASE_ROLE", []string{"db_owner", "admins", "ops"}},
+		{"db_owner", "bob", "SQL_USER", []string{"db_owner", "admins", "ops", "bob"}},
+	}
+	if actual := roleMembershipClosure(memberships, "db_owner"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("roleMembershipClosure(db_owner): expected %v, got %v", expected, actual)
+	}
+
+	if actual := roleMembershipClosure(memberships, "DB_OWNER"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("roleMembershipClosure(DB_OWNER): expected %v, got %v", expected, actual)
+	}
+
+	if actual := roleMembershipClosure(memberships, ""); len(actual) != 9 || actual[0].roleName != "admins" || actual[8].roleName != "ops" {
+		t.Errorf("roleMembershipClosure(): expected 9 memberships of admins, db_owner and ops, got %v", actual)
+	}
+
+	if actual := roleMembershipClosure(memberships, "unknown"); len(actual) != 0 {
+		t.Errorf("roleMembershipClosure(unknown): expected no membership, got %v", actual)
+	}
+}
+
+func testAccDataRoleMembershipGraph(t *testing.T, name string, login string) string {
+	data := map[string]interface{}{"database": "master", "role_name": "graph_role", "username": "graph_user", "login_name": "graph_login", "login_password": "valueIsH8kd$¡"}
+	config := testAccCheckRole(t, name, login, data)
+	text := `resource "mssql_database_role_member" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name = mssql_database_role.{{ .name }}.role_name
+				members   = [mssql_user.{{ .name }}.username]
+			}
+			resource "mssql_database_role_member" "{{ .name }}_nested" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name = "db_datareader"
+				members   = [mssql_database_role.{{ .name }}.role_name]
+			}
+			data "mssql_role_membership_graph" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				role_name  = "db_datareader"
+				depends_on = [mssql_database_role_member.{{ .name }}, mssql_database_role_member.{{ .name }}_nested]
+			}
+			data "mssql_role_membership_graph" "{{ .name }}_server" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				scope     = "SERVER"
+				role_name = "sysadmin"
+			}`
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return config + "\n" + res
+}
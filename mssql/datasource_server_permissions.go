@@ -0,0 +1,93 @@
+package mssql
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func dataSourceServerPermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServerPermissionsRead,
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			principalNameProp: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			principalIdProp: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			permissionsProp: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			withGrantOptionPermissionsProp: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			denyPermissionsProp: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+func dataSourceServerPermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "serverpermissions", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	principalName := data.Get(principalNameProp).(string)
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissions, err := connector.GetServerPermissions(ctx, principalName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read server permissions for principal [%s]", principalName))
+	}
+	if permissions == nil {
+		return diag.Errorf("No principal found for [%s]", principalName)
+	} else {
+		if err = data.Set(principalIdProp, permissions.PrincipalID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(permissionsProp, permissions.Permissions); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(withGrantOptionPermissionsProp, permissions.WithGrantOptionPermissions); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(denyPermissionsProp, permissions.DenyPermissions); err != nil {
+			return diag.FromErr(err)
+		}
+		data.SetId(getServerPermissionsID(data))
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataServerPermissions_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataServerPermissions(t, "data_basic", "login"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_server_permissions.data_basic", "id", "sqlserver://localhost:1433/server_permissions/server_permissions_data_basic"),
+					resource.TestCheckResourceAttr("data.mssql_server_permissions.data_basic", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.mssql_server_permissions.data_basic", "permissions.*", "CONNECT SQL"),
+					resource.TestCheckTypeSetElemAttr("data.mssql_server_permissions.data_basic", "permissions.*", "VIEW SERVER STATE"),
+					resource.TestCheckResourceAttr("data.mssql_server_permissions.data_basic", "deny_permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.mssql_server_permissions.data_basic", "deny_permissions.0", "ALTER ANY LOGIN"),
+					resource.TestCheckResourceAttrPair("data.mssql_server_permissions.data_basic", "principal_id", "mssql_server_permissions.data_basic", "principal_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataServerPermissions(t *testing.T, name string, login string) string {
+	data := map[string]interface{}{"permissions": `["VIEW SERVER STATE"]`, "deny_permissions": `["ALTER ANY LOGIN"]`}
+	config := testAccCheckServerPermissions(t, name, login, data)
+	text := `data "mssql_server_permissions" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				principal_name = mssql_server_permissions.{{ .name }}.principal_name
+			}`
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return config + "\n" + res
+}
@@ -0,0 +1,177 @@
+package mssql
+
+import (
+	"context"
+	"time"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+var (
+	serverLoginTypes     = []string{"SQL_LOGIN", "WINDOWS_LOGIN", "WINDOWS_GROUP", "CERTIFICATE_MAPPED_LOGIN", "ASYMMETRIC_KEY_MAPPED_LOGIN", "EXTERNAL_LOGIN", "EXTERNAL_GROUP"}
+	serverPrincipalTypes = append([]string{"SERVER_ROLE"}, serverLoginTypes...)
+)
+
+func dataSourceServerPrincipals() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServerPrincipalsRead,
+		Schema:      getServerPrincipalsSchema(principalsProp, serverPrincipalTypes),
+		Timeouts: &schema.ResourceTimeout{
+			Read: defaultTimeout,
+		},
+	}
+}
+
+type ServerPrincipalsConnector interface {
+	GetServerPrincipals(ctx context.Context, namePattern, roleName string) ([]model.ServerPrincipal, error)
+}
+
+func getServerPrincipalsSchema(listProp string, types []string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		serverProp: {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Required: true,
+			Elem: &schema.Resource{
+				Schema: getServerSchema(serverProp),
+			},
+		},
+		namePatternProp: {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		typesProp: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(types, false),
+			},
+		},
+		roleNameProp: {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		listProp: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					nameProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					principalIdProp: {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					typeStrProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					sidStrProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					defaultDatabaseProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					defaultLanguageProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					isDisabledProp: {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+					createDateProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					modifyDateProp: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					rolesProp: {
+						Type:     schema.TypeSet,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServerPrincipalsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "server_principals", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	return readServerPrincipals(ctx, data, meta, principalsProp, serverPrincipalTypes)
+}
+
+// readServerPrincipals sets listProp to the server principals matching the filters. Only principals with a type in
+// allowedTypes are returned if the types filter is not set.
+func readServerPrincipals(ctx context.Context, data *schema.ResourceData, meta interface{}, listProp string, allowedTypes []string) diag.Diagnostics {
+	namePattern := data.Get(namePatternProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	types := toStringSlice(data.Get(typesProp).(*schema.Set).List())
+	if len(types) == 0 {
+		types = allowedTypes
+	}
+	typeSet := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		typeSet[t] = struct{}{}
+	}
+
+	connector, err := getServerPrincipalsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	principals, err := connector.GetServerPrincipals(ctx, namePattern, roleName)
+	if err != nil {
+		return diag.FromErr(errors.Wrap(err, "unable to read server principals"))
+	}
+
+	result := make([]map[string]interface{}, 0, len(principals))
+	for _, principal := range principals {
+		if _, ok := typeSet[principal.TypeDesc]; !ok {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			nameProp:            principal.Name,
+			principalIdProp:     principal.PrincipalID,
+			typeStrProp:         principal.TypeDesc,
+			sidStrProp:          principal.SIDStr,
+			defaultDatabaseProp: principal.DefaultDatabase,
+			defaultLanguageProp: principal.DefaultLanguage,
+			isDisabledProp:      principal.IsDisabled,
+			createDateProp:      principal.CreateDate.UTC().Format(time.RFC3339),
+			modifyDateProp:      principal.ModifyDate.UTC().Format(time.RFC3339),
+			rolesProp:           principal.Roles,
+		})
+	}
+	if err = data.Set(listProp, result); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(getServerPrincipalsID(data, listProp))
+
+	return nil
+}
+
+func getServerPrincipalsConnector(meta interface{}, data *schema.ResourceData) (ServerPrincipalsConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(ServerPrincipalsConnector), nil
+}
@@ -0,0 +1,56 @@
+package mssql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataServerPrincipals_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataServerPrincipals(t, "basic", "login", map[string]interface{}{"name_pattern": "sysadmin", "types": `["SERVER_ROLE"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mssql_server_principals.basic", "id", "sqlserver://localhost:1433/principals"),
+					resource.TestCheckResourceAttr("data.mssql_server_principals.basic", "principals.#", "1"),
+					resource.TestCheckResourceAttr("data.mssql_server_principals.basic", "principals.0.name", "sysadmin"),
+					resource.TestCheckResourceAttr("data.mssql_server_principals.basic", "principals.0.type", "SERVER_ROLE"),
+					resource.TestCheckResourceAttr("data.mssql_server_principals.basic", "principals.0.principal_id", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataServerPrincipals(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `data "mssql_server_principals" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .name_pattern }}name_pattern = "{{ . }}"{{ end }}
+				{{ with .role_name }}role_name = "{{ . }}"{{ end }}
+				{{ with .types }}types = {{ . }}{{ end }}
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
@@ -39,6 +39,13 @@ func dataSourceServerRole() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			permissionsProp: {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Read: defaultTimeout,
@@ -77,6 +84,15 @@ func dataSourceServerRoleRead(ctx context.Context, data *schema.ResourceData, me
 		if err = data.Set(ownerIdProp, role.OwnerId); err != nil {
 			return diag.FromErr(err)
 		}
+		permissions, err := connector.GetServerPermissions(ctx, roleName)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to get permissions of role [%s]", roleName))
+		}
+		if permissions != nil {
+			if err = data.Set(permissionsProp, permissions.Permissions); err != nil {
+				return diag.FromErr(err)
+			}
+		}
 		data.SetId(getServerRoleID(data))
 	}
 
@@ -39,6 +39,18 @@ func dataSourceUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			withoutLoginProp: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			certificateNameProp: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			asymmetricKeyNameProp: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			sidStrProp: {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -95,6 +107,15 @@ func dataSourceUserRead(ctx context.Context, data *schema.ResourceData, meta int
 		if err = data.Set(loginNameProp, user.LoginName); err != nil {
 			return diag.FromErr(err)
 		}
+		if err = data.Set(withoutLoginProp, user.WithoutLogin); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(certificateNameProp, user.CertificateName); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(asymmetricKeyNameProp, user.AsymmetricKeyName); err != nil {
+			return diag.FromErr(err)
+		}
 		if err = data.Set(sidStrProp, user.SIDStr); err != nil {
 			return diag.FromErr(err)
 		}
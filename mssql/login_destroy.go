@@ -0,0 +1,102 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+const (
+	onDestroyKillSessions     = "kill_sessions"
+	onDestroyFailIfConnected  = "fail_if_connected"
+	onDestroyWaitDisconnect   = "wait_for_disconnect"
+	onDestroyDisableOnly      = "disable_only"
+	onDestroyDefault          = onDestroyKillSessions
+	loginSessionsPollInterval = 5 * time.Second
+)
+
+type LoginSessionConnector interface {
+	GetLoginSessions(ctx context.Context, name string) ([]model.LoginSession, error)
+	KillLoginSessions(ctx context.Context, name string) error
+	DisableLogin(ctx context.Context, name string) error
+}
+
+func getOnDestroySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  onDestroyDefault,
+		ValidateFunc: validation.StringInSlice([]string{
+			onDestroyKillSessions,
+			onDestroyFailIfConnected,
+			onDestroyWaitDisconnect,
+			onDestroyDisableOnly,
+		}, false),
+	}
+}
+
+// destroyLogin handles the active sessions of the login according to the on_destroy argument and then calls drop,
+// unless the login should only be disabled.
+func destroyLogin(ctx context.Context, data *schema.ResourceData, connector LoginSessionConnector, loginName string, drop func() error) diag.Diagnostics {
+	switch data.Get(onDestroyProp).(string) {
+	case onDestroyDisableOnly:
+		if err := connector.DisableLogin(ctx, loginName); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to disable login [%s]", loginName))
+		}
+		return nil
+	case onDestroyFailIfConnected:
+		sessions, err := connector.GetLoginSessions(ctx, loginName)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read sessions of login [%s]", loginName))
+		}
+		if len(sessions) > 0 {
+			return loginSessionsDiagnostics(loginName, sessions)
+		}
+	case onDestroyWaitDisconnect:
+		for {
+			sessions, err := connector.GetLoginSessions(ctx, loginName)
+			if err != nil {
+				return diag.FromErr(errors.Wrapf(err, "unable to read sessions of login [%s]", loginName))
+			}
+			if len(sessions) == 0 {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return loginSessionsDiagnostics(loginName, sessions)
+			case <-time.After(loginSessionsPollInterval):
+			}
+		}
+	default:
+		if err := connector.KillLoginSessions(ctx, loginName); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to kill sessions of login [%s]", loginName))
+		}
+	}
+
+	if err := drop(); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to delete login [%s]", loginName))
+	}
+	return nil
+}
+
+func loginSessionsDiagnostics(loginName string, sessions []model.LoginSession) diag.Diagnostics {
+	lines := make([]string, len(sessions))
+	for i, session := range sessions {
+		lines[i] = fmt.Sprintf("session %d: host [%s], program [%s], connected since %s",
+			session.SessionID, session.HostName, session.ProgramName, session.LoginTime.UTC().Format(time.RFC3339))
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("login [%s] has %d active session(s)", loginName, len(sessions)),
+			Detail:   strings.Join(lines, "\n"),
+		},
+	}
+}
@@ -0,0 +1,8 @@
+package model
+
+// ApplicationRole represents a SQL Server application role
+type ApplicationRole struct {
+	PrincipalID   int
+	RoleName      string
+	DefaultSchema string
+}
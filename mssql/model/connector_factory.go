@@ -1,7 +1,12 @@
 package model
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
 type ConnectorFactory interface {
-	GetConnector(prefix string, data *schema.ResourceData) (interface{}, error)
+	GetConnector(prefix string, data ResourceData) (interface{}, error)
+}
+
+// ResourceData reads the server block of a resource, from either a *schema.ResourceData or, at plan time, a
+// *schema.ResourceDiff.
+type ResourceData interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
 }
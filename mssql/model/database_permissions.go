@@ -1,8 +1,11 @@
 package model
 
 type DatabasePermissions struct {
-	DatabaseName string
-	UserName     string
-	PrincipalID  int
-	Permissions  []string
+	DatabaseName               string
+	UserName                   string
+	PrincipalID                int
+	Permissions                []string
+	WithGrantOptionPermissions []string
+	DenyPermissions            []string
+	ConnectGranted             bool
 }
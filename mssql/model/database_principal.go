@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type DatabasePrincipal struct {
+	PrincipalID     int64
+	Name            string
+	TypeDesc        string
+	AuthType        string
+	SIDStr          string
+	LoginName       string
+	DefaultSchema   string
+	DefaultLanguage string
+	CreateDate      time.Time
+	ModifyDate      time.Time
+	Roles           []string
+	OwnedSchemas    []string
+}
@@ -7,3 +7,9 @@ type DatabaseRole struct {
 	OwnerName string
 	OwnerId   int
 }
+
+// RolePermission is a permission granted to a role on the database, a schema or an object.
+type RolePermission struct {
+	Permission string
+	Securable  Securable
+}
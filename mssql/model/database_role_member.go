@@ -0,0 +1,6 @@
+package model
+
+type DatabaseRoleMember struct {
+	RoleName string
+	Members  []string
+}
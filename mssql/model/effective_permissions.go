@@ -0,0 +1,8 @@
+package model
+
+// EffectivePermission is a row of sys.fn_my_permissions: a permission on the securable, or on one of its columns when
+// Column is set.
+type EffectivePermission struct {
+	Column     string
+	Permission string
+}
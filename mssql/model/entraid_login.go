@@ -7,4 +7,6 @@ type EntraIDLogin struct {
 	ObjectId        string
 	Sid             string
 	PrincipalID     int
+	IsDisabled      bool
+	IsGroup         bool
 }
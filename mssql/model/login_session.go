@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+type LoginSession struct {
+	SessionID   int
+	HostName    string
+	ProgramName string
+	LoginTime   time.Time
+}
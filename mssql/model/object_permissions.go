@@ -0,0 +1,25 @@
+package model
+
+// Securable identifies a database securable like SCHEMA::[reporting] or OBJECT::[dbo].[usp_x]. Schema is only set for
+// the schema-scoped types OBJECT, TYPE and XML SCHEMA COLLECTION. Columns restricts an OBJECT securable to some of its
+// columns.
+type Securable struct {
+	Type    string
+	Schema  string
+	Name    string
+	Columns []string
+}
+
+// ObjectPermissions holds the permissions of a principal on a securable. Permissions includes the permissions granted
+// WITH GRANT OPTION, which are also listed in WithGrantOptionPermissions. For a securable restricted to columns,
+// OtherColumnPermissions holds the permissions granted or denied to the principal on the other columns of the object.
+type ObjectPermissions struct {
+	DatabaseName               string
+	PrincipalName              string
+	PrincipalID                int
+	Securable                  Securable
+	Permissions                []string
+	WithGrantOptionPermissions []string
+	DenyPermissions            []string
+	OtherColumnPermissions     map[string][]string
+}
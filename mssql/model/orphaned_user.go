@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type OrphanedUser struct {
+	Database    string
+	PrincipalID int64
+	Username    string
+	TypeDesc    string
+	SIDStr      string
+	CreateDate  time.Time
+}
@@ -0,0 +1,17 @@
+package model
+
+import "fmt"
+
+// OwnedObject is a database securable owned by a principal or contained in a schema
+type OwnedObject struct {
+	Class  string
+	Schema string
+	Name   string
+}
+
+func (o OwnedObject) String() string {
+	if o.Schema != "" {
+		return fmt.Sprintf("%s [%s].[%s]", o.Class, o.Schema, o.Name)
+	}
+	return fmt.Sprintf("%s [%s]", o.Class, o.Name)
+}
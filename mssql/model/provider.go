@@ -1,12 +1,14 @@
 package model
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
 	"github.com/rs/zerolog"
 )
 
 type Provider interface {
-	GetConnector(prefix string, data *schema.ResourceData) (interface{}, error)
+	GetConnector(prefix string, data ResourceData) (interface{}, error)
 	ResourceLogger(resource, function string) zerolog.Logger
 	DataSourceLogger(datasource, function string) zerolog.Logger
+	PasswordPolicy() *validate.PasswordPolicy
+	CleanupStringSplitHelper() bool
 }
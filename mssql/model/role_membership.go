@@ -0,0 +1,8 @@
+package model
+
+// RoleMembership is the direct membership of a principal in a database or server role.
+type RoleMembership struct {
+	RoleName   string
+	MemberName string
+	MemberType string
+}
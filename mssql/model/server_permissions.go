@@ -0,0 +1,11 @@
+package model
+
+// ServerPermissions holds the server permissions of a principal. Permissions includes the permissions granted WITH
+// GRANT OPTION, which are also listed in WithGrantOptionPermissions.
+type ServerPermissions struct {
+	PrincipalName              string
+	PrincipalID                int
+	Permissions                []string
+	WithGrantOptionPermissions []string
+	DenyPermissions            []string
+}
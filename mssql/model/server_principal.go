@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type ServerPrincipal struct {
+	PrincipalID     int64
+	Name            string
+	TypeDesc        string
+	SIDStr          string
+	DefaultDatabase string
+	DefaultLanguage string
+	IsDisabled      bool
+	CreateDate      time.Time
+	ModifyDate      time.Time
+	Roles           []string
+}
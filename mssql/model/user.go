@@ -1,15 +1,19 @@
 package model
 
 type User struct {
-	PrincipalID     int64
-	Username        string
-	ObjectId        string
-	LoginName       string
-	Password        string
-	SIDStr          string
-	AuthType        string
-	TypeStr         string
-	DefaultSchema   string
-	DefaultLanguage string
-	Roles           []string
+	PrincipalID       int64
+	Username          string
+	PreviousUsername  string
+	ObjectId          string
+	LoginName         string
+	Password          string
+	WithoutLogin      bool
+	CertificateName   string
+	AsymmetricKeyName string
+	SIDStr            string
+	AuthType          string
+	TypeStr           string
+	DefaultSchema     string
+	DefaultLanguage   string
+	Roles             []string
 }
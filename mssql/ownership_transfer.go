@@ -0,0 +1,72 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+type OwnershipConnector interface {
+	GetOwnedObjects(ctx context.Context, database, principalName string) ([]model.OwnedObject, error)
+	TransferOwnership(ctx context.Context, database, principalName, newOwner string) error
+}
+
+func getOnDestroyTransferOwnershipSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: []string{onDestroyFailOnOwnedProp},
+		ValidateFunc:  validate.SQLIdentifier,
+	}
+}
+
+func getOnDestroyFailOnOwnedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeBool,
+		Optional:      true,
+		Default:       false,
+		ConflictsWith: []string{onDestroyTransferOwnershipToProp},
+	}
+}
+
+// releaseOwnedObjects prepares a database principal to be dropped according to the on_destroy_* ownership arguments:
+// it either fails listing the securables owned by the principal, or transfers them to the configured principal.
+// Without either argument the securables are left to the drop statement.
+func releaseOwnedObjects(ctx context.Context, data *schema.ResourceData, connector OwnershipConnector, database, principalName string) diag.Diagnostics {
+	if data.Get(onDestroyFailOnOwnedProp).(bool) {
+		objects, err := connector.GetOwnedObjects(ctx, database, principalName)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read objects owned by [%s].[%s]", database, principalName))
+		}
+		if len(objects) > 0 {
+			return ownedObjectsDiagnostics(fmt.Sprintf("[%s].[%s] owns %d object(s)", database, principalName, len(objects)), objects)
+		}
+		return nil
+	}
+	if newOwner := data.Get(onDestroyTransferOwnershipToProp).(string); newOwner != "" {
+		if err := connector.TransferOwnership(ctx, database, principalName, newOwner); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to transfer objects owned by [%s].[%s]", database, principalName))
+		}
+	}
+	return nil
+}
+
+func ownedObjectsDiagnostics(summary string, objects []model.OwnedObject) diag.Diagnostics {
+	lines := make([]string, len(objects))
+	for i, object := range objects {
+		lines[i] = object.String()
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   strings.Join(lines, "\n"),
+		},
+	}
+}
@@ -0,0 +1,295 @@
+package mssql
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	passwordLowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordNumericChars = "0123456789"
+	// Only non-word characters count as "non-alphanumeric" for SQLIdentifierPassword, so '_' is left out.
+	passwordSpecialChars       = "!#$%&*()-+=[]{}<>:?"
+	passwordLengthDefault      = 32
+	passwordGenerateMaxRetries = 100
+)
+
+type passwordOptions struct {
+	Length  int
+	Upper   bool
+	Lower   bool
+	Numeric bool
+	Special bool
+}
+
+func getGeneratePasswordSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				lengthProp: {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      passwordLengthDefault,
+					ValidateFunc: validation.IntBetween(8, 128),
+				},
+				upperProp: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				lowerProp: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				numericProp: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				specialProp: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				keepersProp: {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// passwordOptionsFromList converts the generate_password block into passwordOptions.
+// It returns nil if the block is not configured.
+func passwordOptionsFromList(list []interface{}) *passwordOptions {
+	if len(list) == 0 {
+		return nil
+	}
+	opts := &passwordOptions{
+		Length:  passwordLengthDefault,
+		Upper:   true,
+		Lower:   true,
+		Numeric: true,
+		Special: true,
+	}
+	if list[0] == nil {
+		return opts
+	}
+	block := list[0].(map[string]interface{})
+	if v, ok := block[lengthProp].(int); ok && v > 0 {
+		opts.Length = v
+	}
+	if v, ok := block[upperProp].(bool); ok {
+		opts.Upper = v
+	}
+	if v, ok := block[lowerProp].(bool); ok {
+		opts.Lower = v
+	}
+	if v, ok := block[numericProp].(bool); ok {
+		opts.Numeric = v
+	}
+	if v, ok := block[specialProp].(bool); ok {
+		opts.Special = v
+	}
+	return opts
+}
+
+func (o *passwordOptions) charsets() map[string]string {
+	sets := make(map[string]string)
+	if o.Upper {
+		sets[validate.PasswordClassUpper] = passwordUpperChars
+	}
+	if o.Lower {
+		sets[validate.PasswordClassLower] = passwordLowerChars
+	}
+	if o.Numeric {
+		sets[validate.PasswordClassNumeric] = passwordNumericChars
+	}
+	if o.Special {
+		sets[validate.PasswordClassSpecial] = passwordSpecialChars
+	}
+	return sets
+}
+
+// validate checks that passwords generated with these options can satisfy the policy.
+func (o *passwordOptions) validate(policy *validate.PasswordPolicy) error {
+	sets := o.charsets()
+	if len(sets) < policy.MinClasses {
+		return fmt.Errorf("%q must enable at least %d of the character classes %q, %q, %q and %q", generatePasswordProp, policy.MinClasses, upperProp, lowerProp, numericProp, specialProp)
+	}
+	for _, class := range policy.RequiredClasses {
+		if _, ok := sets[class]; !ok {
+			return fmt.Errorf("%q must enable the %q character class required by the password policy", generatePasswordProp, class)
+		}
+	}
+	if o.Length < policy.MinLength || o.Length > policy.MaxLength {
+		return fmt.Errorf("%q must be between %d and %d, got %d", lengthProp, policy.MinLength, policy.MaxLength, o.Length)
+	}
+	return nil
+}
+
+// generatePassword returns a random password containing at least one character of every enabled class and
+// satisfying the password policy.
+func generatePassword(opts *passwordOptions, policy *validate.PasswordPolicy, name string) (string, error) {
+	if err := opts.validate(policy); err != nil {
+		return "", err
+	}
+
+	var errs []error
+	for i := 0; i < passwordGenerateMaxRetries; i++ {
+		password, err := randomPassword(opts)
+		if err != nil {
+			return "", err
+		}
+		if errs = policy.Validate(password, generatedPasswordProp, name); len(errs) == 0 {
+			return password, nil
+		}
+	}
+	return "", errs[0]
+}
+
+func randomPassword(opts *passwordOptions) (string, error) {
+	var sets []string
+	for _, class := range validate.PasswordClasses {
+		if set, ok := opts.charsets()[class]; ok {
+			sets = append(sets, set)
+		}
+	}
+
+	result := make([]byte, 0, opts.Length)
+	for _, set := range sets {
+		c, err := randomChar(set)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, c)
+	}
+	all := strings.Join(sets, "")
+	for len(result) < opts.Length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, c)
+	}
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		result[i], result[j.Int64()] = result[j.Int64()], result[i]
+	}
+	return string(result), nil
+}
+
+func randomChar(set string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	if err != nil {
+		return 0, err
+	}
+	return set[n.Int64()], nil
+}
+
+// resolvePassword returns the password to send to the server. When generate_password is configured a new
+// password is generated if rotate is true, otherwise the previously generated one is reused.
+func resolvePassword(data *schema.ResourceData, meta interface{}, name string, rotate bool) (string, error) {
+	opts := passwordOptionsFromList(data.Get(generatePasswordProp).([]interface{}))
+	if opts == nil {
+		if err := data.Set(generatedPasswordProp, ""); err != nil {
+			return "", err
+		}
+		return data.Get(passwordProp).(string), nil
+	}
+	if !rotate {
+		return data.Get(generatedPasswordProp).(string), nil
+	}
+	password, err := generatePassword(opts, passwordPolicyFromMeta(meta), name)
+	if err != nil {
+		return "", err
+	}
+	if err = data.Set(generatedPasswordProp, password); err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
+func customizeDiffGeneratedPassword(ctx context.Context, data *schema.ResourceDiff, meta interface{}) error {
+	opts := passwordOptionsFromList(data.Get(generatePasswordProp).([]interface{}))
+	if opts == nil {
+		if data.Get(generatedPasswordProp).(string) != "" {
+			return data.SetNew(generatedPasswordProp, "")
+		}
+		return nil
+	}
+	if err := opts.validate(passwordPolicyFromMeta(meta)); err != nil {
+		return err
+	}
+	if data.Id() != "" && data.HasChange(generatePasswordProp) {
+		return data.SetNewComputed(generatedPasswordProp)
+	}
+	return nil
+}
+
+// customizeDiffPasswordPolicy checks the password, or the write-only password, against the provider password
+// policy. nameProp is the attribute holding the principal name, or "" if the password does not belong to a principal.
+func customizeDiffPasswordPolicy(nameProp string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, data *schema.ResourceDiff, meta interface{}) error {
+		if !data.NewValueKnown(passwordProp) {
+			return nil
+		}
+		prop, password := passwordProp, data.Get(passwordProp).(string)
+		if password == "" {
+			prop, password = passwordWOProp, writeOnlyPassword(data)
+		}
+		if password == "" {
+			return nil
+		}
+		var name string
+		if nameProp != "" {
+			name = data.Get(nameProp).(string)
+		}
+		if errs := passwordPolicyFromMeta(meta).Validate(password, prop, name); len(errs) > 0 {
+			return errs[0]
+		}
+		return nil
+	}
+}
+
+// writeOnlyPassword returns the write-only password from the configuration, or "" if the resource has none or it is
+// not set or not known yet. Write-only values are never stored, so they can only be read from the raw configuration.
+func writeOnlyPassword(data *schema.ResourceDiff) string {
+	config := data.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(passwordWOProp) {
+		return ""
+	}
+	value := config.GetAttr(passwordWOProp)
+	if value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return ""
+	}
+	return value.AsString()
+}
+
+func passwordPolicyFromMeta(meta interface{}) *validate.PasswordPolicy {
+	if provider, ok := meta.(model.Provider); ok && provider.PasswordPolicy() != nil {
+		return provider.PasswordPolicy()
+	}
+	return validate.DefaultPasswordPolicy()
+}
@@ -0,0 +1,140 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestGeneratePassword_Defaults(t *testing.T) {
+	opts := passwordOptionsFromList([]interface{}{nil})
+	for i := 0; i < 100; i++ {
+		password, err := generatePassword(opts, validate.DefaultPasswordPolicy(), "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(password) != passwordLengthDefault {
+			t.Fatalf("expected length %d, got %d", passwordLengthDefault, len(password))
+		}
+		if _, errs := validate.SQLIdentifierPassword(password, generatedPasswordProp); len(errs) > 0 {
+			t.Fatalf("generated password %q does not satisfy SQLIdentifierPassword: %v", password, errs)
+		}
+	}
+}
+
+func TestGeneratePassword_Classes(t *testing.T) {
+	opts := &passwordOptions{Length: 8, Upper: true, Lower: true, Numeric: true}
+	for i := 0; i < 100; i++ {
+		password, err := generatePassword(opts, validate.DefaultPasswordPolicy(), "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.ContainsAny(password, passwordSpecialChars) {
+			t.Fatalf("expected no special characters, got %q", password)
+		}
+		if !strings.ContainsAny(password, passwordUpperChars) || !strings.ContainsAny(password, passwordLowerChars) || !strings.ContainsAny(password, passwordNumericChars) {
+			t.Fatalf("expected every enabled class in %q", password)
+		}
+	}
+}
+
+func TestGeneratePassword_TooFewClasses(t *testing.T) {
+	opts := &passwordOptions{Length: 16, Lower: true, Numeric: true}
+	if _, err := generatePassword(opts, validate.DefaultPasswordPolicy(), ""); err == nil {
+		t.Fatal("expected an error when fewer than three character classes are enabled")
+	}
+}
+
+func TestGeneratePassword_Policy(t *testing.T) {
+	policy := &validate.PasswordPolicy{
+		MinLength:       20,
+		MaxLength:       64,
+		MinClasses:      4,
+		RequiredClasses: []string{validate.PasswordClassSpecial},
+		ForbidName:      true,
+	}
+	opts := passwordOptionsFromList([]interface{}{nil})
+	for i := 0; i < 100; i++ {
+		password, err := generatePassword(opts, policy, "a")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.ContainsAny(password, "aA") {
+			t.Fatalf("generated password %q contains the principal name", password)
+		}
+	}
+
+	opts.Length = 16
+	if _, err := generatePassword(opts, policy, ""); err == nil {
+		t.Fatal("expected an error when the length is below the policy minimum")
+	}
+
+	opts = &passwordOptions{Length: 32, Upper: true, Lower: true, Numeric: true}
+	policy.MinClasses = 3
+	if _, err := generatePassword(opts, policy, ""); err == nil {
+		t.Fatal("expected an error when a required character class is disabled")
+	}
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := &validate.PasswordPolicy{
+		MinLength:           8,
+		MaxLength:           128,
+		MinClasses:          3,
+		ForbiddenSubstrings: []string{"contoso"},
+		ForbidName:          true,
+		Dictionary:          map[string]struct{}{"p@ssw0rd!": {}},
+	}
+	cases := map[string]struct {
+		password string
+		valid    bool
+	}{
+		"valid":      {"Xk9#mQ2!vL", true},
+		"short":      {"Xk9#", false},
+		"classes":    {"abcdefghij1", false},
+		"substring":  {"Contoso-2024!", false},
+		"name":       {"Admin-2024!x", false},
+		"dictionary": {"P@ssw0rd!", false},
+	}
+	for name, c := range cases {
+		errs := policy.Validate(c.password, passwordProp, "admin")
+		if c.valid && len(errs) > 0 {
+			t.Errorf("%s: unexpected errors: %v", name, errs)
+		}
+		if !c.valid && len(errs) == 0 {
+			t.Errorf("%s: expected an error for %q", name, c.password)
+		}
+	}
+}
+
+func TestCustomizeDiffPasswordPolicy_WriteOnly(t *testing.T) {
+	r := resourceApplicationRole()
+	diff := func(password string) error {
+		config := cty.ObjectVal(map[string]cty.Value{
+			serverProp:            cty.NullVal(r.CoreConfigSchema().BlockTypes[serverProp].ImpliedType()),
+			databaseProp:          cty.NullVal(cty.String),
+			roleNameProp:          cty.StringVal("app_role"),
+			defaultSchemaProp:     cty.NullVal(cty.String),
+			passwordProp:          cty.NullVal(cty.String),
+			passwordWOProp:        cty.StringVal(password),
+			passwordWOVersionProp: cty.NumberIntVal(1),
+			principalIdProp:       cty.NullVal(cty.Number),
+			"id":                  cty.NullVal(cty.String),
+			"timeouts":            cty.NullVal(r.CoreConfigSchema().BlockTypes["timeouts"].ImpliedType()),
+		})
+		// Terraform passes the raw configuration to the diff through the prior state, as on create
+		state := &terraform.InstanceState{RawConfig: config}
+		_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(config, r.CoreConfigSchema()), nil)
+		return err
+	}
+	if err := diff("weakpassword"); err == nil || !strings.Contains(err.Error(), passwordWOProp) {
+		t.Fatalf("expected %s to be checked against the default policy, got %v", passwordWOProp, err)
+	}
+	if err := diff("valueIsH8kd$¡"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
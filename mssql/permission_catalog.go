@@ -0,0 +1,139 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+type PermissionCatalogConnector interface {
+	GetBuiltinPermissions(ctx context.Context) (map[string][]string, error)
+}
+
+// permissionCatalogs caches the builtin permissions by class of every server, keyed by host and port.
+var permissionCatalogs = struct {
+	sync.Mutex
+	servers map[string]map[string][]string
+}{servers: make(map[string]map[string][]string)}
+
+// permissionClassFunc returns the securable class and the permission name of a configured permission.
+type permissionClassFunc func(diff *schema.ResourceDiff, permission string) (class string, name string)
+
+func databasePermissionClass(_ *schema.ResourceDiff, permission string) (string, string) {
+	return "DATABASE", permission
+}
+
+func objectPermissionClass(diff *schema.ResourceDiff, permission string) (string, string) {
+	return diff.Get(securableTypeProp).(string), permission
+}
+
+// serverPermissionClass splits a server permission like ALTER ON LOGIN::name into the LOGIN class and the ALTER
+// permission. Permissions without ON apply to the SERVER class.
+func serverPermissionClass(_ *schema.ResourceDiff, permission string) (string, string) {
+	name, on, found := strings.Cut(permission, " ON ")
+	if !found {
+		return "SERVER", permission
+	}
+	class, _, _ := strings.Cut(on, "::")
+	return class, name
+}
+
+// customizeDiffPermissionCatalog checks the changed permissions of props against the builtin permissions of the
+// server at plan time. The check is skipped when the server or the permissions are not known yet, or when the server
+// cannot be reached.
+func customizeDiffPermissionCatalog(permissionClass permissionClassFunc, props ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		var permissions []catalogPermission
+		for _, prop := range props {
+			if !diff.NewValueKnown(prop) {
+				return nil
+			}
+			if diff.HasChange(prop) {
+				for _, permission := range toStringSlice(diff.Get(prop).(*schema.Set).List()) {
+					class, name := permissionClass(diff, permission)
+					permissions = append(permissions, catalogPermission{class: class, name: name})
+				}
+			}
+		}
+		return checkCatalogPermissions(ctx, diff, meta, permissions)
+	}
+}
+
+// catalogPermission is a permission name and the securable class it is granted on.
+type catalogPermission struct {
+	class string
+	name  string
+}
+
+// checkCatalogPermissions checks permissions against the builtin permissions of the server of the resource. The check
+// is skipped when the server is not known yet or cannot be reached.
+func checkCatalogPermissions(ctx context.Context, diff *schema.ResourceDiff, meta interface{}, permissions []catalogPermission) error {
+	if len(permissions) == 0 || !diff.NewValueKnown(serverProp) || diff.Get(serverProp+".0.host").(string) == "" {
+		return nil
+	}
+
+	catalog, err := getPermissionCatalog(ctx, diff, meta)
+	if err != nil {
+		logger := loggerFromMeta(meta, "permissioncatalog", "customizediff")
+		logger.Warn().Err(err).Msg("Skipping the validation of permission names")
+		return nil
+	}
+
+	var problems []string
+	for _, permission := range permissions {
+		if problem := checkPermissionCatalog(catalog, permission.class, permission.name); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("invalid permissions: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkPermissionCatalog returns why the permission is not valid on class, or an empty string if it is.
+func checkPermissionCatalog(catalog map[string][]string, class string, permission string) string {
+	if containsString(catalog[class], permission) {
+		return ""
+	}
+	var classes []string
+	for other, permissions := range catalog {
+		if containsString(permissions, permission) {
+			classes = append(classes, other)
+		}
+	}
+	if len(classes) == 0 {
+		return fmt.Sprintf("permission [%s] does not exist", permission)
+	}
+	sort.Strings(classes)
+	return fmt.Sprintf("permission [%s] does not apply to %s, only to %s", permission, class, strings.Join(classes, ", "))
+}
+
+// getPermissionCatalog returns the builtin permissions of the server of the resource, reading them once per server.
+func getPermissionCatalog(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) (map[string][]string, error) {
+	key := fmt.Sprintf("%s:%s", diff.Get(serverProp+".0.host"), diff.Get(serverProp+".0.port"))
+
+	permissionCatalogs.Lock()
+	defer permissionCatalogs.Unlock()
+
+	if catalog, ok := permissionCatalogs.servers[key]; ok {
+		return catalog, nil
+	}
+
+	connector, err := meta.(model.Provider).GetConnector(serverProp, diff)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := connector.(PermissionCatalogConnector).GetBuiltinPermissions(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the builtin permissions of server [%s]", key)
+	}
+	permissionCatalogs.servers[key] = catalog
+	return catalog, nil
+}
@@ -0,0 +1,42 @@
+package mssql
+
+import (
+	"testing"
+)
+
+func TestCheckPermissionCatalog(t *testing.T) {
+	catalog := map[string][]string{
+		"DATABASE": {"SELECT", "VIEW DEFINITION", "ALTER ANY USER"},
+		"OBJECT":   {"SELECT", "VIEW DEFINITION", "EXECUTE"},
+		"SCHEMA":   {"SELECT", "EXECUTE"},
+	}
+	for _, tc := range []struct {
+		class, permission, expected string
+	}{
+		{"DATABASE", "VIEW DEFINITION", ""},
+		{"OBJECT", "EXECUTE", ""},
+		{"DATABASE", "VIEW DEFINTION", "permission [VIEW DEFINTION] does not exist"},
+		{"OBJECT", "ALTER ANY USER", "permission [ALTER ANY USER] does not apply to OBJECT, only to DATABASE"},
+		{"ASSEMBLY", "EXECUTE", "permission [EXECUTE] does not apply to ASSEMBLY, only to OBJECT, SCHEMA"},
+	} {
+		if actual := checkPermissionCatalog(catalog, tc.class, tc.permission); actual != tc.expected {
+			t.Errorf("checkPermissionCatalog(%s, %s): expected %q, got %q", tc.class, tc.permission, tc.expected, actual)
+		}
+	}
+}
+
+func TestServerPermissionClass(t *testing.T) {
+	for _, tc := range []struct {
+		permission, class, name string
+	}{
+		{"VIEW SERVER STATE", "SERVER", "VIEW SERVER STATE"},
+		{"IMPERSONATE ON LOGIN::app", "LOGIN", "IMPERSONATE"},
+		{"ALTER ON SERVER ROLE::ops team", "SERVER ROLE", "ALTER"},
+		{"CONNECT ON ENDPOINT::Hadr", "ENDPOINT", "CONNECT"},
+	} {
+		class, name := serverPermissionClass(nil, tc.permission)
+		if class != tc.class || name != tc.name {
+			t.Errorf("serverPermissionClass(%s): expected %s/%s, got %s/%s", tc.permission, tc.class, tc.name, class, name)
+		}
+	}
+}
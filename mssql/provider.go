@@ -8,16 +8,20 @@ import (
 	"time"
 
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
 	"github.com/ValeruS/terraform-provider-mssql/sql"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
 type mssqlProvider struct {
-	factory model.ConnectorFactory
-	logger  *zerolog.Logger
+	factory        model.ConnectorFactory
+	logger         *zerolog.Logger
+	passwordPolicy *validate.PasswordPolicy
+	cleanupHelper  bool
 }
 
 const (
@@ -43,21 +47,85 @@ func Provider(factory model.ConnectorFactory) *schema.Provider {
 				Optional:    true,
 				Default:     false,
 			},
+			cleanupStringSplitProp: {
+				Type:        schema.TypeBool,
+				Description: "Drop the [dbo].[String_Split] helper function created by earlier provider versions from the databases of managed users",
+				Optional:    true,
+				Default:     false,
+			},
+			passwordPolicyProp: {
+				Type:        schema.TypeList,
+				Description: "Password complexity rules enforced at plan time for logins, contained users and database master keys",
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						minLengthProp: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      8,
+							ValidateFunc: validation.IntBetween(1, 128),
+						},
+						maxLengthProp: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      128,
+							ValidateFunc: validation.IntBetween(1, 128),
+						},
+						minClassesProp: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3,
+							ValidateFunc: validation.IntBetween(0, len(validate.PasswordClasses)),
+						},
+						requiredClassesProp: {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(validate.PasswordClasses, false),
+							},
+						},
+						forbiddenSubstrProp: {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						forbidNameProp: {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						dictionaryFileProp: {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"mssql_login":                     resourceLogin(),
-			"mssql_user":                      resourceUser(),
-			"mssql_database_permissions":      resourceDatabasePermissions(),
-			"mssql_database_role":             resourceDatabaseRole(),
-			"mssql_database_schema":           resourceDatabaseSchema(),
-			"mssql_database_masterkey":        resourceDatabaseMasterkey(),
-			"mssql_database_credential":       resourceDatabaseCredential(),
-			"mssql_azure_external_datasource": resourceAzureExternalDatasource(),
-			"mssql_database_sqlscript":        resourceDatabaseSQLScript(),
-			"mssql_entraid_login":             resourceEntraIDLogin(),
-			"mssql_server_role":               resourceServerRole(),
-			"mssql_server_role_member":        resourceServerRoleMember(),
-			"mssql_database":                  resourceDatabase(),
+			"mssql_login":                      resourceLogin(),
+			"mssql_user":                       resourceUser(),
+			"mssql_database_permissions":       resourceDatabasePermissions(),
+			"mssql_database_role":              resourceDatabaseRole(),
+			"mssql_database_schema":            resourceDatabaseSchema(),
+			"mssql_database_masterkey":         resourceDatabaseMasterkey(),
+			"mssql_database_credential":        resourceDatabaseCredential(),
+			"mssql_azure_external_datasource":  resourceAzureExternalDatasource(),
+			"mssql_database_sqlscript":         resourceDatabaseSQLScript(),
+			"mssql_entraid_login":              resourceEntraIDLogin(),
+			"mssql_server_role":                resourceServerRole(),
+			"mssql_server_role_member":         resourceServerRoleMember(),
+			"mssql_database":                   resourceDatabase(),
+			"mssql_server_permissions":         resourceServerPermissions(),
+			"mssql_database_role_member":       resourceDatabaseRoleMember(),
+			"mssql_multi_database_user":        resourceMultiDatabaseUser(),
+			"mssql_multi_database_permissions": resourceMultiDatabasePermissions(),
+			"mssql_object_permissions":         resourceObjectPermissions(),
+			"mssql_application_role":           resourceApplicationRole(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"mssql_login":                     dataSourceLogin(),
@@ -71,6 +139,16 @@ func Provider(factory model.ConnectorFactory) *schema.Provider {
 			"mssql_server_role":               dataSourceServerRole(),
 			"mssql_server_role_member":        dataSourceServerRoleMember(),
 			"mssql_database":                  dataSourceDatabase(),
+			"mssql_logins":                    dataSourceLogins(),
+			"mssql_server_principals":         dataSourceServerPrincipals(),
+			"mssql_server_permissions":        dataSourceServerPermissions(),
+			"mssql_orphaned_users":            dataSourceOrphanedUsers(),
+			"mssql_database_role_member":      dataSourceDatabaseRoleMember(),
+			"mssql_database_principals":       dataSourceDatabasePrincipals(),
+			"mssql_database_users":            dataSourceDatabaseUsers(),
+			"mssql_effective_permissions":     dataSourceEffectivePermissions(),
+			"mssql_application_role":          dataSourceApplicationRole(),
+			"mssql_role_membership_graph":     dataSourceRoleMembershipGraph(),
 		},
 		ConfigureContextFunc: func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
 			return providerConfigure(ctx, data, factory)
@@ -82,12 +160,42 @@ func providerConfigure(ctx context.Context, data *schema.ResourceData, factory m
 	isDebug := data.Get("debug").(bool)
 	logger := newLogger(isDebug)
 
+	passwordPolicy, err := passwordPolicyFromList(data.Get(passwordPolicyProp).([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
 	logger.Info().Msg("Created provider")
 
-	return mssqlProvider{factory: factory, logger: logger}, nil
+	return mssqlProvider{factory: factory, logger: logger, passwordPolicy: passwordPolicy, cleanupHelper: data.Get(cleanupStringSplitProp).(bool)}, nil
 }
 
-func (p mssqlProvider) GetConnector(prefix string, data *schema.ResourceData) (interface{}, error) {
+func passwordPolicyFromList(list []interface{}) (*validate.PasswordPolicy, error) {
+	policy := validate.DefaultPasswordPolicy()
+	if len(list) == 0 || list[0] == nil {
+		return policy, nil
+	}
+	block := list[0].(map[string]interface{})
+	policy.MinLength = block[minLengthProp].(int)
+	policy.MaxLength = block[maxLengthProp].(int)
+	policy.MinClasses = block[minClassesProp].(int)
+	policy.RequiredClasses = toStringSlice(block[requiredClassesProp].(*schema.Set).List())
+	policy.ForbiddenSubstrings = toStringSlice(block[forbiddenSubstrProp].(*schema.Set).List())
+	policy.ForbidName = block[forbidNameProp].(bool)
+	if policy.MinLength > policy.MaxLength {
+		return nil, fmt.Errorf("%s.%s (%d) cannot be greater than %s (%d)", passwordPolicyProp, minLengthProp, policy.MinLength, maxLengthProp, policy.MaxLength)
+	}
+	if path := block[dictionaryFileProp].(string); path != "" {
+		dictionary, err := validate.LoadPasswordDictionary(path)
+		if err != nil {
+			return nil, err
+		}
+		policy.Dictionary = dictionary
+	}
+	return policy, nil
+}
+
+func (p mssqlProvider) GetConnector(prefix string, data model.ResourceData) (interface{}, error) {
 	return p.factory.GetConnector(prefix, data)
 }
 
@@ -99,6 +207,14 @@ func (p mssqlProvider) DataSourceLogger(datasource, function string) zerolog.Log
 	return p.logger.With().Str("datasource", datasource).Str("func", function).Logger()
 }
 
+func (p mssqlProvider) PasswordPolicy() *validate.PasswordPolicy {
+	return p.passwordPolicy
+}
+
+func (p mssqlProvider) CleanupStringSplitHelper() bool {
+	return p.cleanupHelper
+}
+
 func newLogger(isDebug bool) *zerolog.Logger {
 	var writer io.Writer = nil
 	logLevel := zerolog.Disabled
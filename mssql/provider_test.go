@@ -64,6 +64,7 @@ type TestConnector interface {
 	GetUser(database, name string) (*model.User, error)
 	GetDatabasePermissions(database, name string) (*model.DatabasePermissions, error)
 	GetDatabaseRole(database, name string) (*model.DatabaseRole, error)
+	GetApplicationRole(database, name string) (*model.ApplicationRole, error)
 	GetDatabaseSchema(database, name string) (*model.DatabaseSchema, error)
 	GetDatabaseCredential(database, name string) (*model.DatabaseCredential, error)
 	GetAzureExternalDatasource(database, name string) (*model.AzureExternalDatasource, error)
@@ -72,7 +73,10 @@ type TestConnector interface {
 	GetEntraIDLogin(name string) (*model.EntraIDLogin, error)
 	GetServerRole(name string) (*model.ServerRole, error)
 	GetServerRoleMember(roleName string, managedMembers []string) (*model.ServerRoleMember, error)
+	GetServerPermissions(principalName string) (*model.ServerPermissions, error)
+	GetDatabaseRoleMember(database, roleName string, managedMembers []string) (*model.DatabaseRoleMember, error)
 	GetDatabase(name string) (*model.Database, error)
+	GetObjectPermissions(database, principalName string, securable model.Securable) (*model.ObjectPermissions, error)
 	GetSystemUser() (string, error)
 	GetCurrentUser(database string) (string, string, error)
 }
@@ -115,7 +119,11 @@ func getTestLoginConnector(a map[string]string) (TestConnector, error) {
 		Port:    a[prefix+"port"],
 		Timeout: 60 * time.Second,
 	}
-	if password, ok := a[passwordProp]; ok {
+	password := a[passwordProp]
+	if password == "" {
+		password = a[generatedPasswordProp]
+	}
+	if password != "" {
 		connector.Login = &sql.LoginUser{
 			Username: a[loginNameProp],
 			Password: password,
@@ -178,6 +186,10 @@ func (t testConnector) GetDatabaseRole(database string, roleName string) (*model
 	return t.c.(DatabaseRoleConnector).GetDatabaseRole(context.Background(), database, roleName)
 }
 
+func (t testConnector) GetApplicationRole(database string, roleName string) (*model.ApplicationRole, error) {
+	return t.c.(ApplicationRoleConnector).GetApplicationRole(context.Background(), database, roleName)
+}
+
 func (t testConnector) GetDatabaseSchema(database string, schemaName string) (*model.DatabaseSchema, error) {
 	return t.c.(DatabaseSchemaConnector).GetDatabaseSchema(context.Background(), database, schemaName)
 }
@@ -214,6 +226,18 @@ func (t testConnector) GetServerRoleMember(roleName string, managedMembers []str
 	return t.c.(ServerRoleMemberConnector).GetServerRoleMember(context.Background(), roleName, managedMembers)
 }
 
+func (t testConnector) GetServerPermissions(principalName string) (*model.ServerPermissions, error) {
+	return t.c.(ServerPermissionsConnector).GetServerPermissions(context.Background(), principalName)
+}
+
+func (t testConnector) GetDatabaseRoleMember(database, roleName string, managedMembers []string) (*model.DatabaseRoleMember, error) {
+	return t.c.(DatabaseRoleMemberConnector).GetDatabaseRoleMember(context.Background(), database, roleName, managedMembers)
+}
+
+func (t testConnector) GetObjectPermissions(database, principalName string, securable model.Securable) (*model.ObjectPermissions, error) {
+	return t.c.(ObjectPermissionsConnector).GetObjectPermissions(context.Background(), database, principalName, securable)
+}
+
 func (t testConnector) GetSystemUser() (string, error) {
 	var user string
 	err := t.c.(*sql.Connector).QueryRowContext(context.Background(), "SELECT SYSTEM_USER;", func(row *sql2.Row) error {
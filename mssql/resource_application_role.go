@@ -0,0 +1,314 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceApplicationRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceApplicationRoleCreate,
+		ReadContext:   resourceApplicationRoleRead,
+		UpdateContext: resourceApplicationRoleUpdate,
+		DeleteContext: resourceApplicationRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceApplicationRoleImport,
+		},
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  defaultDatabaseDefault,
+			},
+			roleNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			defaultSchemaProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultDboPropDefault,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			passwordProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{passwordProp, passwordWOProp},
+				ValidateFunc: validate.SQLPasswordLength,
+			},
+			passwordWOProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{passwordProp, passwordWOProp},
+				RequiredWith: []string{passwordWOVersionProp},
+				ValidateFunc: validate.SQLPasswordLength,
+			},
+			passwordWOVersionProp: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{passwordWOProp},
+			},
+			principalIdProp: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: customizeDiffPasswordPolicy(roleNameProp),
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Update: defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type ApplicationRoleConnector interface {
+	CreateApplicationRole(ctx context.Context, database, roleName, password, defaultSchema string) error
+	GetApplicationRole(ctx context.Context, database, roleName string) (*model.ApplicationRole, error)
+	UpdateApplicationRole(ctx context.Context, database, roleName, newRoleName, password, defaultSchema string) error
+	DeleteApplicationRole(ctx context.Context, database, roleName string) error
+	DatabaseExists(ctx context.Context, database string) (bool, error)
+}
+
+func resourceApplicationRoleCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "applicationrole", "create")
+	logger.Debug().Msgf("Create %s", getApplicationRoleID(data))
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	defaultSchema := data.Get(defaultSchemaProp).(string)
+
+	password, err := applicationRolePassword(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.CreateApplicationRole(ctx, database, roleName, password, defaultSchema); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to create application role [%s].[%s]", database, roleName))
+	}
+
+	data.SetId(getApplicationRoleID(data))
+
+	logger.Info().Msgf("created application role [%s].[%s]", database, roleName)
+
+	return resourceApplicationRoleRead(ctx, data, meta)
+}
+
+func resourceApplicationRoleRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "applicationrole", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Check if database exists
+	exists, err := connector.DatabaseExists(ctx, database)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to check if database [%s] exists", database))
+	}
+	if !exists {
+		logger.Info().Msgf("Database [%s] does not exist", database)
+		data.SetId("")
+		return nil
+	}
+
+	role, err := connector.GetApplicationRole(ctx, database, roleName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to get application role [%s].[%s]", database, roleName))
+	}
+
+	if role == nil {
+		logger.Info().Msgf("application role [%s].[%s] does not exist", database, roleName)
+		data.SetId("")
+	} else {
+		if err = data.Set(principalIdProp, role.PrincipalID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(roleNameProp, role.RoleName); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(defaultSchemaProp, role.DefaultSchema); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceApplicationRoleUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "applicationrole", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	oldRoleName, newRoleName := data.GetChange(roleNameProp)
+	roleName := oldRoleName.(string)
+
+	// Store old values for all properties that might change
+	oldValues := make(map[string]interface{})
+	for _, prop := range []string{roleNameProp, defaultSchemaProp, passwordProp, passwordWOVersionProp} {
+		if data.HasChange(prop) {
+			oldValue, _ := data.GetChange(prop)
+			oldValues[prop] = oldValue
+		}
+	}
+
+	var password, defaultSchema string
+	if data.HasChanges(passwordProp, passwordWOVersionProp) {
+		var err error
+		if password, err = applicationRolePassword(data); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if data.HasChange(defaultSchemaProp) {
+		defaultSchema = data.Get(defaultSchemaProp).(string)
+	}
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.UpdateApplicationRole(ctx, database, roleName, newRoleName.(string), password, defaultSchema); err != nil {
+		for prop, oldValue := range oldValues {
+			if setErr := data.Set(prop, oldValue); setErr != nil {
+				logger.Error().Err(setErr).Msgf("Failed to revert %s state after update error", prop)
+			}
+		}
+		return diag.FromErr(errors.Wrapf(err, "unable to update application role [%s].[%s]", database, roleName))
+	}
+
+	data.SetId(getApplicationRoleID(data))
+
+	logger.Info().Msgf("updated application role [%s].[%s]", database, newRoleName)
+
+	return resourceApplicationRoleRead(ctx, data, meta)
+}
+
+func resourceApplicationRoleDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "applicationrole", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.DeleteApplicationRole(ctx, database, roleName); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to delete application role [%s].[%s]", database, roleName))
+	}
+
+	data.SetId("")
+
+	logger.Info().Msgf("deleted application role [%s].[%s]", database, roleName)
+
+	return nil
+}
+
+func resourceApplicationRoleImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(meta, "applicationrole", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	server, u, err := serverFromId(data.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err := data.Set(serverProp, server); err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 4 {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(databaseProp, parts[1]); err != nil {
+		return nil, err
+	}
+	if err = data.Set(roleNameProp, parts[3]); err != nil {
+		return nil, err
+	}
+
+	data.SetId(getApplicationRoleID(data))
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getApplicationRoleConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := connector.GetApplicationRole(ctx, database, roleName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get application role [%s].[%s]", database, roleName)
+	}
+
+	if role == nil {
+		return nil, errors.Errorf("application role [%s].[%s] does not exist", database, roleName)
+	}
+
+	if err = data.Set(principalIdProp, role.PrincipalID); err != nil {
+		return nil, err
+	}
+	if err = data.Set(defaultSchemaProp, role.DefaultSchema); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+// applicationRolePassword returns the configured password, or the write-only password from the configuration.
+func applicationRolePassword(data *schema.ResourceData) (string, error) {
+	if password := data.Get(passwordProp).(string); password != "" {
+		return password, nil
+	}
+	value, diags := data.GetRawConfigAt(cty.GetAttrPath(passwordWOProp))
+	if diags.HasError() {
+		return "", errors.Errorf("unable to read %s: %s", passwordWOProp, diags[0].Summary)
+	}
+	if value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+		return "", errors.Errorf("one of %s or %s must be set", passwordProp, passwordWOProp)
+	}
+	return value.AsString(), nil
+}
+
+func getApplicationRoleConnector(meta interface{}, data *schema.ResourceData) (ApplicationRoleConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(ApplicationRoleConnector), nil
+}
@@ -0,0 +1,182 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccApplicationRole_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckApplicationRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckApplicationRole(t, "local_app_role", "login", map[string]interface{}{"role_name": "test_app_role", "password": "weakpassword"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must contain characters from"),
+			},
+			{
+				Config: testAccCheckApplicationRole(t, "local_app_role", "login", map[string]interface{}{"role_name": "test_app_role", "password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationRoleExists("mssql_application_role.local_app_role", Check{"default_schema", "==", "dbo"}),
+					resource.TestCheckResourceAttr("mssql_application_role.local_app_role", "database", "master"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_app_role", "role_name", "test_app_role"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_app_role", "default_schema", "dbo"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_app_role", "password", "valueIsH8kd$¡"),
+					resource.TestCheckResourceAttrSet("mssql_application_role.local_app_role", "principal_id"),
+				),
+			},
+			{
+				// Renamed, moved to another schema and granted permissions in place
+				Config: testAccCheckApplicationRole(t, "local_app_role", "login", map[string]interface{}{"role_name": "test_app_role_renamed", "password": "valueIsH8kd$¡2", "default_schema": "guest", "permissions": `["SELECT", "EXECUTE"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationRoleExists("mssql_application_role.local_app_role", Check{"default_schema", "==", "guest"}),
+					resource.TestCheckResourceAttr("mssql_application_role.local_app_role", "role_name", "test_app_role_renamed"),
+					resource.TestCheckResourceAttr("mssql_application_role.local_app_role", "default_schema", "guest"),
+					resource.TestCheckResourceAttrPair("mssql_application_role.local_app_role", "principal_id", "mssql_database_permissions.local_app_role", "principal_id"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.local_app_role", "permissions.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApplicationRole_Local_BasicImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckApplicationRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckApplicationRole(t, "app_role_import", "login", map[string]interface{}{"role_name": "test_app_role_import", "password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationRoleExists("mssql_application_role.app_role_import"),
+				),
+			},
+			{
+				ResourceName:            "mssql_application_role.app_role_import",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+				ImportStateIdFunc:       testAccImportStateId("mssql_application_role.app_role_import", false),
+			},
+		},
+	})
+}
+
+func testAccCheckApplicationRole(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_application_role" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .database }}database = "{{ . }}"{{ end }}
+				role_name = "{{ .role_name }}"
+				{{ with .default_schema }}default_schema = "{{ . }}"{{ end }}
+				password  = "{{ .password }}"
+			}
+			{{ with .permissions }}
+			resource "mssql_database_permissions" "{{ $.name }}" {
+				server {
+					host = "{{ $.host }}"
+					{{if eq $.login "fedauth"}}azuread_default_chain_auth {}{{ else if eq $.login "msi"}}azuread_managed_identity_auth {}{{ else if eq $.login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				database    = mssql_application_role.{{ $.name }}.database
+				username    = mssql_application_role.{{ $.name }}.role_name
+				permissions = {{ . }}
+			}
+			{{ end }}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckApplicationRoleDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_application_role" {
+			continue
+		}
+
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		database := rs.Primary.Attributes["database"]
+		roleName := rs.Primary.Attributes["role_name"]
+		role, err := connector.GetApplicationRole(database, roleName)
+		if role != nil {
+			return fmt.Errorf("application role still exists")
+		}
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+	}
+	return nil
+}
+
+func testAccCheckApplicationRoleExists(resource string, checks ...Check) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_application_role" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_application_role", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		database := rs.Primary.Attributes["database"]
+		roleName := rs.Primary.Attributes["role_name"]
+		role, err := connector.GetApplicationRole(database, roleName)
+		if err != nil {
+			return fmt.Errorf("error: %s", err)
+		}
+		if role == nil {
+			return fmt.Errorf("application role %s does not exist", roleName)
+		}
+
+		var actual interface{}
+		for _, check := range checks {
+			switch check.name {
+			case "default_schema":
+				actual = role.DefaultSchema
+			default:
+				return fmt.Errorf("unknown property %s", check.name)
+			}
+			if (check.op == "" || check.op == "==") && !equal(check.expected, actual) {
+				return fmt.Errorf("expected %s == %s, got %s", check.name, check.expected, actual)
+			}
+			if check.op == "!=" && equal(check.expected, actual) {
+				return fmt.Errorf("expected %s != %s, got %s", check.name, check.expected, actual)
+			}
+		}
+		return nil
+	}
+}
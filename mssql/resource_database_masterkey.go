@@ -35,7 +35,7 @@ func resourceDatabaseMasterkey() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				Sensitive:    true,
-				ValidateFunc: validate.SQLIdentifierPassword,
+				ValidateFunc: validate.SQLPasswordLength,
 			},
 			keynameProp: {
 				Type:     schema.TypeString,
@@ -66,6 +66,7 @@ func resourceDatabaseMasterkey() *schema.Resource {
 				Computed: true,
 			},
 		},
+		CustomizeDiff: customizeDiffPasswordPolicy(""),
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
 			Read:   defaultTimeout,
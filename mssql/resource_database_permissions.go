@@ -7,10 +7,14 @@ import (
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
 	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 )
 
+const connectPermission = "CONNECT"
+
 func resourceDatabasePermissions() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceDatabasePermissionsCreate,
@@ -46,14 +50,48 @@ func resourceDatabasePermissions() *schema.Resource {
 				Computed: true,
 			},
 			permissionsProp: {
-				Type:     schema.TypeSet,
-				Required: true,
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: databasePermissionsStateProps,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.SQLIdentifierPermission,
+				},
+			},
+			withGrantOptionPermissionsProp: {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: databasePermissionsStateProps,
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
 					ValidateFunc: validate.SQLIdentifierPermission,
 				},
 			},
+			denyPermissionsProp: {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: databasePermissionsStateProps,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.SQLIdentifierPermission,
+				},
+			},
+			modeProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      modeAuthoritative,
+				ValidateFunc: validation.StringInSlice([]string{modeAdditive, modeAuthoritative}, false),
+			},
+			includeConnectProp: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffDatabasePermissionStates,
+			customizeDiffPermissionCatalog(databasePermissionClass, databasePermissionsStateProps...),
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
 			Read:   defaultTimeout,
@@ -63,6 +101,9 @@ func resourceDatabasePermissions() *schema.Resource {
 	}
 }
 
+// databasePermissionsStateProps hold the permissions by state: granted, granted WITH GRANT OPTION and denied.
+var databasePermissionsStateProps = []string{permissionsProp, withGrantOptionPermissionsProp, denyPermissionsProp}
+
 type DatabasePermissionsConnector interface {
 	CreateDatabasePermissions(ctx context.Context, dbPermission *model.DatabasePermissions) error
 	GetDatabasePermissions(ctx context.Context, database string, username string) (*model.DatabasePermissions, error)
@@ -84,10 +125,24 @@ func resourceDatabasePermissionsCreate(ctx context.Context, data *schema.Resourc
 		return diag.FromErr(err)
 	}
 
+	if data.Get(modeProp).(string) == modeAuthoritative {
+		current, err := connector.GetDatabasePermissions(ctx, database, username)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read permissions for user [%s] on database [%s]", username, database))
+		}
+		if current != nil {
+			if err = revokeUnmanagedDatabasePermissions(ctx, data, connector, current); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	dbPermissionModel := &model.DatabasePermissions{
-		DatabaseName: database,
-		UserName:     username,
-		Permissions:  toStringSlice(permissions),
+		DatabaseName:               database,
+		UserName:                   username,
+		Permissions:                toStringSlice(permissions),
+		WithGrantOptionPermissions: toStringSlice(data.Get(withGrantOptionPermissionsProp).(*schema.Set).List()),
+		DenyPermissions:            toStringSlice(data.Get(denyPermissionsProp).(*schema.Set).List()),
 	}
 	if err = connector.CreateDatabasePermissions(ctx, dbPermissionModel); err != nil {
 		return diag.FromErr(errors.Wrapf(err, "unable to create database permissions [%s] on database [%s] for user [%s]", strings.Join(toStringSlice(permissions), ", "), database, username))
@@ -140,7 +195,19 @@ func resourceDatabasePermissionsRead(ctx context.Context, data *schema.ResourceD
 		if err = data.Set(principalIdProp, permissions.PrincipalID); err != nil {
 			return diag.FromErr(err)
 		}
-		if err = data.Set(permissionsProp, permissions.Permissions); err != nil {
+		authoritative := data.Get(modeProp).(string) == modeAuthoritative
+		managed := data.Get(permissionsProp).(*schema.Set)
+		granted := permissions.Permissions
+		if permissions.ConnectGranted && (data.Get(includeConnectProp).(bool) || managed.Contains(connectPermission)) {
+			granted = append([]string{connectPermission}, granted...)
+		}
+		if err = data.Set(permissionsProp, managedPermissions(granted, managed, authoritative, "")); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(withGrantOptionPermissionsProp, managedPermissions(permissions.WithGrantOptionPermissions, data.Get(withGrantOptionPermissionsProp).(*schema.Set), authoritative, "")); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(denyPermissionsProp, managedPermissions(permissions.DenyPermissions, data.Get(denyPermissionsProp).(*schema.Set), authoritative, "")); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -171,9 +238,11 @@ func resourceDatabasePermissionDelete(ctx context.Context, data *schema.Resource
 	}
 
 	dbPermissionModel := &model.DatabasePermissions{
-		DatabaseName: database,
-		UserName:     username,
-		Permissions:  toStringSlice(permissions),
+		DatabaseName:               database,
+		UserName:                   username,
+		Permissions:                toStringSlice(permissions),
+		WithGrantOptionPermissions: toStringSlice(data.Get(withGrantOptionPermissionsProp).(*schema.Set).List()),
+		DenyPermissions:            toStringSlice(data.Get(denyPermissionsProp).(*schema.Set).List()),
 	}
 	if err = connector.DeleteDatabasePermissions(ctx, dbPermissionModel); err != nil {
 		// If update fails, revert all changed values in the state
@@ -198,19 +267,16 @@ func resourceDatabasePermissionUpdate(ctx context.Context, data *schema.Resource
 
 	database := data.Get(databaseProp).(string)
 	username := data.Get(usernameProp).(string)
-	oldVal, newVal := data.GetChange(permissionsProp)
-	oldPermissions := oldVal.(*schema.Set)
-	newPermissions := newVal.(*schema.Set)
-
-	toGrant, toRevoke := stringSetDiff(oldPermissions, newPermissions)
 
 	// Store old values for all properties that might change
 	oldValues := make(map[string]interface{})
-	for _, prop := range []string{permissionsProp} {
+	for _, prop := range append([]string{modeProp, includeConnectProp}, databasePermissionsStateProps...) {
 		if data.HasChange(prop) {
 			oldValue, _ := data.GetChange(prop)
 			if oldSet, ok := oldValue.(*schema.Set); ok {
 				oldValues[prop] = oldSet.List()
+			} else {
+				oldValues[prop] = oldValue
 			}
 		}
 	}
@@ -220,24 +286,29 @@ func resourceDatabasePermissionUpdate(ctx context.Context, data *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	if len(toGrant) > 0 {
-		if err = connector.UpdateDatabasePermissions(ctx, database, username, toGrant, "GRANT"); err != nil {
-			for prop, oldValue := range oldValues {
-				if setErr := data.Set(prop, oldValue); setErr != nil {
-					logger.Error().Err(setErr).Msgf("Failed to revert %s state after update error", prop)
-				}
+	if data.HasChanges(modeProp, includeConnectProp) && data.Get(modeProp).(string) == modeAuthoritative {
+		current, err := connector.GetDatabasePermissions(ctx, database, username)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read permissions for user [%s] on database [%s]", username, database))
+		}
+		if current != nil {
+			if err = revokeUnmanagedDatabasePermissions(ctx, data, connector, current); err != nil {
+				return diag.FromErr(err)
 			}
-			return diag.FromErr(errors.Wrapf(err, "unable to grant permissions for user [%s] on database [%s]", username, database))
 		}
 	}
-	if len(toRevoke) > 0 {
-		if err = connector.UpdateDatabasePermissions(ctx, database, username, toRevoke, "REVOKE"); err != nil {
+
+	for _, change := range databasePermissionChanges(data) {
+		if len(change.permissions) == 0 {
+			continue
+		}
+		if err = connector.UpdateDatabasePermissions(ctx, database, username, change.permissions, change.changeType); err != nil {
 			for prop, oldValue := range oldValues {
 				if setErr := data.Set(prop, oldValue); setErr != nil {
 					logger.Error().Err(setErr).Msgf("Failed to revert %s state after update error", prop)
 				}
 			}
-			return diag.FromErr(errors.Wrapf(err, "unable to revoke permissions for user [%s] on database [%s]", username, database))
+			return diag.FromErr(errors.Wrapf(err, "unable to update permissions for user [%s] on database [%s]", username, database))
 		}
 	}
 
@@ -271,6 +342,12 @@ func resourceDatabasePermissionImport(ctx context.Context, data *schema.Resource
 	if err = data.Set(usernameProp, parts[3]); err != nil {
 		return nil, err
 	}
+	if err = data.Set(modeProp, modeAuthoritative); err != nil {
+		return nil, err
+	}
+	if err = data.Set(includeConnectProp, false); err != nil {
+		return nil, err
+	}
 
 	database := data.Get(databaseProp).(string)
 	username := data.Get(usernameProp).(string)
@@ -303,10 +380,118 @@ func resourceDatabasePermissionImport(ctx context.Context, data *schema.Resource
 	if err = data.Set(permissionsProp, permissions.Permissions); err != nil {
 		return nil, err
 	}
+	if err = data.Set(withGrantOptionPermissionsProp, permissions.WithGrantOptionPermissions); err != nil {
+		return nil, err
+	}
+	if err = data.Set(denyPermissionsProp, permissions.DenyPermissions); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{data}, nil
 }
 
+// databasePermissionStates returns the state of every permission in the old or new configuration: G for granted, W for
+// granted WITH GRANT OPTION and D for denied.
+func databasePermissionStates(data *schema.ResourceData, old bool) map[string]string {
+	states := make(map[string]string)
+	for prop, state := range map[string]string{permissionsProp: "G", withGrantOptionPermissionsProp: "W", denyPermissionsProp: "D"} {
+		oldValue, newValue := data.GetChange(prop)
+		value := newValue
+		if old {
+			value = oldValue
+		}
+		for _, permission := range toStringSlice(value.(*schema.Set).List()) {
+			states[permission] = state
+		}
+	}
+	return states
+}
+
+// permissionChange is a change type applied to a list of permissions.
+type permissionChange struct {
+	permissions []string
+	changeType  string
+}
+
+// databasePermissionChanges returns the statements moving every permission from its old state to its new state, in
+// the order they must be applied.
+func databasePermissionChanges(data *schema.ResourceData) []permissionChange {
+	oldStates := databasePermissionStates(data, true)
+	newStates := databasePermissionStates(data, false)
+	changes := make(map[string][]string)
+	for permission := range oldStates {
+		if _, ok := newStates[permission]; !ok {
+			changes["REVOKE"] = append(changes["REVOKE"], permission)
+		}
+	}
+	for permission, newState := range newStates {
+		oldState := oldStates[permission]
+		if oldState == newState {
+			continue
+		}
+		switch newState {
+		case "G":
+			if oldState == "W" {
+				changes["REVOKE GRANT OPTION FOR"] = append(changes["REVOKE GRANT OPTION FOR"], permission)
+			} else {
+				changes["GRANT"] = append(changes["GRANT"], permission)
+			}
+		case "W":
+			changes["GRANT WITH GRANT OPTION"] = append(changes["GRANT WITH GRANT OPTION"], permission)
+		case "D":
+			changes["DENY"] = append(changes["DENY"], permission)
+		}
+	}
+	var result []permissionChange
+	for _, changeType := range []string{"REVOKE", "REVOKE GRANT OPTION FOR", "GRANT", "GRANT WITH GRANT OPTION", "DENY"} {
+		result = append(result, permissionChange{changes[changeType], changeType})
+	}
+	return result
+}
+
+// revokeUnmanagedDatabasePermissions revokes the permissions of the user that are not configured in the state they are
+// held in. CONNECT is only revoked when include_connect is set.
+func revokeUnmanagedDatabasePermissions(ctx context.Context, data *schema.ResourceData, connector DatabasePermissionsConnector, current *model.DatabasePermissions) error {
+	wanted := databasePermissionStates(data, false)
+	granted := current.Permissions
+	if current.ConnectGranted && data.Get(includeConnectProp).(bool) {
+		granted = append([]string{connectPermission}, granted...)
+	}
+	var toRevoke []string
+	for state, permissions := range map[string][]string{"G": granted, "W": current.WithGrantOptionPermissions, "D": current.DenyPermissions} {
+		for _, permission := range permissions {
+			if wanted[permission] != state {
+				toRevoke = append(toRevoke, permission)
+			}
+		}
+	}
+	if len(toRevoke) == 0 {
+		return nil
+	}
+	if err := connector.UpdateDatabasePermissions(ctx, current.DatabaseName, current.UserName, toRevoke, "REVOKE"); err != nil {
+		return errors.Wrapf(err, "unable to revoke unmanaged permissions from user [%s] on database [%s]", current.UserName, current.DatabaseName)
+	}
+	return nil
+}
+
+// customizeDiffDatabasePermissionStates fails if a permission is configured in more than one state, or if
+// include_connect is set in additive mode.
+func customizeDiffDatabasePermissionStates(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Get(includeConnectProp).(bool) && diff.Get(modeProp).(string) != modeAuthoritative {
+		return errors.Errorf("%s requires %s = \"%s\"", includeConnectProp, modeProp, modeAuthoritative)
+	}
+	seen := make(map[string]string)
+	for _, prop := range databasePermissionsStateProps {
+		for _, permission := range toStringSlice(diff.Get(prop).(*schema.Set).List()) {
+			if other, ok := seen[permission]; ok {
+				return errors.Errorf("permission %s cannot be in both %s and %s", permission, other, prop)
+			}
+			seen[permission] = prop
+		}
+	}
+	return nil
+}
+
 func getDatabasePermissionsConnector(meta interface{}, data *schema.ResourceData) (DatabasePermissionsConnector, error) {
 	provider := meta.(model.Provider)
 	connector, err := provider.GetConnector(serverProp, data)
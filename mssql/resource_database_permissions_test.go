@@ -3,6 +3,7 @@ package mssql
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -205,6 +206,127 @@ func TestAccDatabasePermissions_Azure_Basic(t *testing.T) {
 	})
 }
 
+func TestAccDatabasePermissions_Local_GrantOptionAndDeny(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabasePermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_states", "permissions": "[\"SELECT\"]", "with_grant_option_permissions": "[\"EXECUTE\"]", "deny_permissions": "[\"DELETE\"]", "login_name": "db_login_perm_states", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabasePermissionsExist("mssql_database_permissions.database"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "permissions.0", "SELECT"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "with_grant_option_permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "with_grant_option_permissions.0", "EXECUTE"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "deny_permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "deny_permissions.0", "DELETE"),
+				),
+			},
+			{
+				// EXECUTE loses its grant option, SELECT becomes denied and INSERT is granted with grant option
+				Config: testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_states", "permissions": "[\"EXECUTE\"]", "with_grant_option_permissions": "[\"INSERT\"]", "deny_permissions": "[\"DELETE\", \"SELECT\"]", "login_name": "db_login_perm_states", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabasePermissionsExist("mssql_database_permissions.database"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "permissions.0", "EXECUTE"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "with_grant_option_permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "with_grant_option_permissions.0", "INSERT"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "deny_permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mssql_database_permissions.database", "deny_permissions.*", "DELETE"),
+					resource.TestCheckTypeSetElemAttr("mssql_database_permissions.database", "deny_permissions.*", "SELECT"),
+				),
+			},
+			{
+				// Permissions revoked or denied outside of Terraform are restored
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "REVOKE INSERT FROM [db_user_perm_states] CASCADE; GRANT SELECT TO [db_user_perm_states]"); err != nil {
+						t.Fatalf("unable to change permissions: %s", err)
+					}
+				},
+				Config: testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_states", "permissions": "[\"EXECUTE\"]", "with_grant_option_permissions": "[\"INSERT\"]", "deny_permissions": "[\"DELETE\", \"SELECT\"]", "login_name": "db_login_perm_states", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "with_grant_option_permissions.0", "INSERT"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "deny_permissions.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatabasePermissions_Local_Mode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabasePermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_mode", "permissions": "[\"SELECT\"]", "login_name": "db_login_perm_mode", "login_password": "valueIsH8kd$¡", "mode": "additive"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabasePermissionsExist("mssql_database_permissions.database"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "mode", "additive"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "permissions.#", "1"),
+				),
+			},
+			{
+				// Permissions granted outside of Terraform are ignored in additive mode
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "GRANT INSERT TO [db_user_perm_mode]; DENY UPDATE TO [db_user_perm_mode]"); err != nil {
+						t.Fatalf("unable to change permissions: %s", err)
+					}
+				},
+				Config:   testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_mode", "permissions": "[\"SELECT\"]", "login_name": "db_login_perm_mode", "login_password": "valueIsH8kd$¡", "mode": "additive"}),
+				PlanOnly: true,
+			},
+			{
+				// and revoked in authoritative mode
+				Config: testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_mode", "permissions": "[\"SELECT\"]", "login_name": "db_login_perm_mode", "login_password": "valueIsH8kd$¡", "mode": "authoritative"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabasePermissionsExist("mssql_database_permissions.database"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "deny_permissions.#", "0"),
+				),
+			},
+			{
+				// CONNECT is only managed with include_connect
+				Config: testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_mode", "permissions": "[\"SELECT\"]", "login_name": "db_login_perm_mode", "login_password": "valueIsH8kd$¡", "mode": "authoritative", "include_connect": "true"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabasePermissionsExist("mssql_database_permissions.database"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_permissions.database", "permissions.0", "SELECT"),
+				),
+			},
+			{
+				Config:      testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_mode", "permissions": "[\"SELECT\"]", "login_name": "db_login_perm_mode", "login_password": "valueIsH8kd$¡", "mode": "additive", "include_connect": "true"}),
+				ExpectError: regexp.MustCompile("include_connect requires mode = \"authoritative\""),
+			},
+		},
+	})
+}
+
+func TestAccDatabasePermissions_Local_InvalidPermission(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_invalid", "permissions": "[\"SELECT\", \"VIEW DEFINTION\"]", "login_name": "db_login_perm_invalid", "login_password": "valueIsH8kd$¡"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`permission \[VIEW DEFINTION\] does not exist`),
+			},
+			{
+				Config:      testAccCheckDatabasePermissions(t, "database", "login", map[string]interface{}{"database": "master", "username": "db_user_perm_invalid", "deny_permissions": "[\"ALTER ANY LOGIN\"]", "login_name": "db_login_perm_invalid", "login_password": "valueIsH8kd$¡"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`permission \[ALTER ANY LOGIN\] does not apply to DATABASE, only to SERVER`),
+			},
+		},
+	})
+}
+
 func testAccCheckDatabasePermissions(t *testing.T, name string, login string, data map[string]interface{}) string {
 	text := `{{ if .login_name }}
 			resource "mssql_login" "{{ .name }}" {
@@ -239,7 +361,11 @@ func testAccCheckDatabasePermissions(t *testing.T, name string, login string, da
 				}
 				database     = "{{ .database }}"
 				username = mssql_user.{{ .name }}.username
-				permissions  = {{ .permissions }}
+				{{ with .permissions }}permissions = {{ . }}{{ end }}
+				{{ with .with_grant_option_permissions }}with_grant_option_permissions = {{ . }}{{ end }}
+				{{ with .deny_permissions }}deny_permissions = {{ . }}{{ end }}
+				{{ with .mode }}mode = "{{ . }}"{{ end }}
+				{{ with .include_connect }}include_connect = {{ . }}{{ end }}
 			}`
 
 	data["name"] = name
@@ -56,7 +56,11 @@ func resourceDatabaseRole() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			onDestroyTransferOwnershipToProp: getOnDestroyTransferOwnershipSchema(),
+			onDestroyFailOnOwnedProp:         getOnDestroyFailOnOwnedSchema(),
+			permissionsProp:                  getDatabaseRolePermissionsSchema(false),
 		},
+		CustomizeDiff: customizeDiffDatabaseRolePermissions,
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
 			Read:   defaultTimeout,
@@ -73,6 +77,8 @@ type DatabaseRoleConnector interface {
 	UpdateDatabaseRoleOwner(ctx context.Context, database string, roleName string, ownerName string) error
 	DeleteDatabaseRole(ctx context.Context, database, roleName string) error
 	DatabaseExists(ctx context.Context, database string) (bool, error)
+	OwnershipConnector
+	DatabaseRolePermissionsConnector
 }
 
 func resourceDatabaseRoleCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -94,6 +100,11 @@ func resourceDatabaseRoleCreate(ctx context.Context, data *schema.ResourceData,
 
 	data.SetId(getDatabaseRoleID(data))
 
+	permissions := rolePermissionsFromSet(data.Get(permissionsProp).(*schema.Set))
+	if err = updateDatabaseRolePermissions(ctx, connector, database, roleName, permissions, "GRANT"); err != nil {
+		return diag.FromErr(err)
+	}
+
 	logger.Info().Msgf("created role [%s].[%s]", database, roleName)
 
 	return resourceDatabaseRoleRead(ctx, data, meta)
@@ -143,6 +154,18 @@ func resourceDatabaseRoleRead(ctx context.Context, data *schema.ResourceData, me
 		if err = data.Set(ownerIdProp, role.OwnerId); err != nil {
 			return diag.FromErr(err)
 		}
+
+		// Permissions are only managed when the state holds some, which is also the case after a failed update;
+		// all of them are then reported so that grants made outside of the role show up as drift.
+		if configured := rolePermissionsFromSet(data.Get(permissionsProp).(*schema.Set)); len(configured) > 0 {
+			current, err := connector.GetDatabaseRolePermissions(ctx, database, roleName)
+			if err != nil {
+				return diag.FromErr(errors.Wrapf(err, "unable to get permissions of role [%s].[%s]", database, roleName))
+			}
+			if err = data.Set(permissionsProp, flattenRolePermissions(current, configured)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
 	}
 
 	logger.Info().Msgf("read role [%s].[%s]", database, roleName)
@@ -162,6 +185,10 @@ func resourceDatabaseRoleDelete(ctx context.Context, data *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if diags := releaseOwnedObjects(ctx, data, connector, database, roleName); diags.HasError() {
+		return diags
+	}
+
 	if err = connector.DeleteDatabaseRole(ctx, database, roleName); err != nil {
 		return diag.FromErr(errors.Wrapf(err, "unable to delete role [%s].[%s]", database, roleName))
 	}
@@ -215,6 +242,21 @@ func resourceDatabaseRoleUpdate(ctx context.Context, data *schema.ResourceData,
 		}
 	}
 
+	if data.HasChange(permissionsProp) {
+		oldPermissions, newPermissions := data.GetChange(permissionsProp)
+		toGrant, toRevoke := diffRolePermissions(rolePermissionsFromSet(oldPermissions.(*schema.Set)), rolePermissionsFromSet(newPermissions.(*schema.Set)))
+		err = updateDatabaseRolePermissions(ctx, connector, database, roleName, toRevoke, "REVOKE")
+		if err == nil {
+			err = updateDatabaseRolePermissions(ctx, connector, database, roleName, toGrant, "GRANT")
+		}
+		if err != nil {
+			if setErr := data.Set(permissionsProp, oldPermissions); setErr != nil {
+				logger.Error().Err(setErr).Msg("Failed to revert permissions state after update error")
+			}
+			return diag.FromErr(err)
+		}
+	}
+
 	data.SetId(getDatabaseRoleID(data))
 
 	logger.Info().Msgf("updated role [%s].[%s]", database, roleName)
@@ -270,6 +312,9 @@ func resourceDatabaseRoleImport(ctx context.Context, data *schema.ResourceData,
 	if err = data.Set(ownerNameProp, role.OwnerName); err != nil {
 		return nil, err
 	}
+	if err = data.Set(onDestroyFailOnOwnedProp, false); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{data}, nil
 }
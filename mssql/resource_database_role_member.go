@@ -0,0 +1,238 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceDatabaseRoleMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDatabaseRoleMemberCreate,
+		ReadContext:   resourceDatabaseRoleMemberRead,
+		UpdateContext: resourceDatabaseRoleMemberUpdate,
+		DeleteContext: resourceDatabaseRoleMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDatabaseRoleMemberImport,
+		},
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  defaultDatabaseDefault,
+			},
+			roleNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			membersProp: {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Update: defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type DatabaseRoleMemberConnector interface {
+	GetDatabaseRoleMember(ctx context.Context, database, roleName string, managedMembers []string) (*model.DatabaseRoleMember, error)
+	UpdateDatabaseRoleMember(ctx context.Context, database, roleName string, members []string, changeType string) error
+}
+
+func resourceDatabaseRoleMemberCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "database_role_member", "create")
+	logger.Debug().Msgf("Create %s", getDatabaseRoleMemberID(data))
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	members := toStringSlice(data.Get(membersProp).(*schema.Set).List())
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.UpdateDatabaseRoleMember(ctx, database, roleName, members, "ADD"); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to add members [%s] to role [%s].[%s]", strings.Join(members, ", "), database, roleName))
+	}
+
+	data.SetId(getDatabaseRoleMemberID(data))
+
+	logger.Info().Msgf("added members [%s] to role [%s].[%s]", strings.Join(members, ", "), database, roleName)
+
+	return resourceDatabaseRoleMemberRead(ctx, data, meta)
+}
+
+func resourceDatabaseRoleMemberRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "database_role_member", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	managedMembers := toStringSlice(data.Get(membersProp).(*schema.Set).List())
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	roleMembers, err := connector.GetDatabaseRoleMember(ctx, database, roleName, managedMembers)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to get role members for role [%s].[%s]", database, roleName))
+	}
+
+	if roleMembers == nil {
+		logger.Info().Msgf("role [%s].[%s] does not exist", database, roleName)
+		data.SetId("")
+	} else {
+		if err = data.Set(membersProp, roleMembers.Members); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceDatabaseRoleMemberUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "database_role_member", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	oldVal, newVal := data.GetChange(membersProp)
+	toAdd, toRemove := stringSetDiff(oldVal.(*schema.Set), newVal.(*schema.Set))
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	revert := func() {
+		if setErr := data.Set(membersProp, oldVal.(*schema.Set).List()); setErr != nil {
+			logger.Error().Err(setErr).Msgf("Failed to revert %s state after update error", membersProp)
+		}
+	}
+	if len(toRemove) > 0 {
+		if err = connector.UpdateDatabaseRoleMember(ctx, database, roleName, toRemove, "DROP"); err != nil {
+			revert()
+			return diag.FromErr(errors.Wrapf(err, "unable to remove members from role [%s].[%s]", database, roleName))
+		}
+		logger.Info().Msgf("removed members [%s] from role [%s].[%s]", strings.Join(toRemove, ", "), database, roleName)
+	}
+	if len(toAdd) > 0 {
+		if err = connector.UpdateDatabaseRoleMember(ctx, database, roleName, toAdd, "ADD"); err != nil {
+			revert()
+			return diag.FromErr(errors.Wrapf(err, "unable to add members to role [%s].[%s]", database, roleName))
+		}
+		logger.Info().Msgf("added members [%s] to role [%s].[%s]", strings.Join(toAdd, ", "), database, roleName)
+	}
+
+	return resourceDatabaseRoleMemberRead(ctx, data, meta)
+}
+
+func resourceDatabaseRoleMemberDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "database_role_member", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	managedMembers := toStringSlice(data.Get(membersProp).(*schema.Set).List())
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.UpdateDatabaseRoleMember(ctx, database, roleName, managedMembers, "DROP"); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to delete role members for role [%s].[%s]", database, roleName))
+	}
+
+	data.SetId("")
+
+	logger.Info().Msgf("deleted role members for role [%s].[%s]", database, roleName)
+
+	return nil
+}
+
+func resourceDatabaseRoleMemberImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(meta, "database_role_member", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	server, u, err := serverFromId(data.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err = data.Set(serverProp, server); err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 4 {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(databaseProp, parts[1]); err != nil {
+		return nil, err
+	}
+	if err = data.Set(roleNameProp, parts[3]); err != nil {
+		return nil, err
+	}
+
+	data.SetId(getDatabaseRoleMemberID(data))
+
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getDatabaseRoleMemberConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	roleMembers, err := connector.GetDatabaseRoleMember(ctx, database, roleName, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read role members for role [%s].[%s] for import", database, roleName)
+	}
+	if roleMembers == nil {
+		return nil, errors.Errorf("no role [%s].[%s] found for import", database, roleName)
+	}
+
+	// Import every current member of the role.
+	if err = data.Set(membersProp, roleMembers.Members); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+func getDatabaseRoleMemberConnector(meta interface{}, data *schema.ResourceData) (DatabaseRoleMemberConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(DatabaseRoleMemberConnector), nil
+}
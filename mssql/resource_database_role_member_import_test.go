@@ -0,0 +1,31 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDatabaseRoleMember_Local_BasicImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabaseRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "test_import", "login", map[string]interface{}{"role_name": "test_role_member_import", "members": "[\"role_member_user_0\", \"role_member_user_2\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.test_import"),
+				),
+			},
+			{
+				ResourceName:      "mssql_database_role_member.test_import",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateId("mssql_database_role_member.test_import", false),
+			},
+		},
+	})
+}
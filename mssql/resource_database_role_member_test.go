@@ -0,0 +1,229 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDatabaseRoleMember_Local_Basic_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabaseRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "local_test_create", "login", map[string]interface{}{"role_name": "test_role_member_create", "members": "[\"role_member_user_1\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.local_test_create"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "database", "master"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "role_name", "test_role_member_create"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "members.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "members.0", "role_member_user_1"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "server.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "server.0.host", "localhost"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "server.0.login.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "server.0.login.0.username", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_create", "server.0.azure_login.#", "0"),
+				),
+			},
+			{
+				// mssql_user with manage_roles = false must not fight over memberships managed here.
+				Config:   testAccCheckDatabaseRoleMember(t, "local_test_create", "login", map[string]interface{}{"role_name": "test_role_member_create", "members": "[\"role_member_user_1\"]"}),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccDatabaseRoleMember_Local_Basic_Update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabaseRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "local_test_update", "login", map[string]interface{}{"role_name": "test_role_member_update", "members": "[\"role_member_user_0\", \"role_member_user_1\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.local_test_update"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_update", "members.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mssql_database_role_member.local_test_update", "members.*", "role_member_user_0"),
+					resource.TestCheckTypeSetElemAttr("mssql_database_role_member.local_test_update", "members.*", "role_member_user_1"),
+				),
+			},
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "local_test_update", "login", map[string]interface{}{"role_name": "test_role_member_update", "members": "[\"role_member_user_1\", \"role_member_user_2\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.local_test_update"),
+					testAccCheckDatabaseRoleMemberNotExists("mssql_database_role_member.local_test_update", "role_member_user_0"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.local_test_update", "members.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mssql_database_role_member.local_test_update", "members.*", "role_member_user_1"),
+					resource.TestCheckTypeSetElemAttr("mssql_database_role_member.local_test_update", "members.*", "role_member_user_2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDatabaseRoleMember_Azure_Basic_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckDatabaseRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckDatabaseRoleMember(t, "azure_test_create", "azure", map[string]interface{}{"database": "testdb", "role_name": "test_role_member_create", "members": "[\"role_member_user_1\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDatabaseRoleMemberExists("mssql_database_role_member.azure_test_create"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.azure_test_create", "database", "testdb"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.azure_test_create", "role_name", "test_role_member_create"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.azure_test_create", "members.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.azure_test_create", "members.0", "role_member_user_1"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.azure_test_create", "server.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.azure_test_create", "server.0.host", os.Getenv("TF_ACC_SQL_SERVER")),
+					resource.TestCheckResourceAttr("mssql_database_role_member.azure_test_create", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.azure_test_create", "server.0.azure_login.#", "1"),
+					resource.TestCheckResourceAttr("mssql_database_role_member.azure_test_create", "server.0.login.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDatabaseRoleMember(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_user" "{{ .name }}" {
+				count = 3
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .database }}database = "{{ . }}"{{ end }}
+				username      = "role_member_user_${count.index}"
+				without_login = true
+				manage_roles  = false
+			}
+			resource "mssql_database_role" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .database }}database = "{{ . }}"{{ end }}
+				role_name = "{{ .role_name }}"
+			}
+			resource "mssql_database_role_member" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .database }}database = "{{ . }}"{{ end }}
+				role_name  = mssql_database_role.{{ .name }}.role_name
+				members    = {{ .members }}
+				depends_on = [mssql_user.{{ .name }}]
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckDatabaseRoleMemberDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_database_role_member" {
+			continue
+		}
+
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		database := rs.Primary.Attributes["database"]
+		roleName := rs.Primary.Attributes["role_name"]
+		managedMembers := getManagedMembersFromState(rs.Primary.Attributes)
+		roleMembers, err := connector.GetDatabaseRoleMember(database, roleName, managedMembers)
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if roleMembers != nil && len(roleMembers.Members) > 0 {
+			return fmt.Errorf("role members still exist")
+		}
+	}
+	return nil
+}
+
+func testAccCheckDatabaseRoleMemberExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_database_role_member" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_database_role_member", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		database := rs.Primary.Attributes["database"]
+		roleName := rs.Primary.Attributes["role_name"]
+		managedMembers := getManagedMembersFromState(rs.Primary.Attributes)
+		roleMembers, err := connector.GetDatabaseRoleMember(database, roleName, managedMembers)
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if roleMembers == nil {
+			return fmt.Errorf("role [%s].[%s] does not exist", database, roleName)
+		}
+		if len(roleMembers.Members) != len(managedMembers) {
+			return fmt.Errorf("expected %d role members, got %d", len(managedMembers), len(roleMembers.Members))
+		}
+		return nil
+	}
+}
+
+func testAccCheckDatabaseRoleMemberNotExists(resource string, member string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		database := rs.Primary.Attributes["database"]
+		roleName := rs.Primary.Attributes["role_name"]
+		roleMembers, err := connector.GetDatabaseRoleMember(database, roleName, []string{member})
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if roleMembers != nil && len(roleMembers.Members) > 0 {
+			return fmt.Errorf("member %s is still in role [%s].[%s]", member, database, roleName)
+		}
+		return nil
+	}
+}
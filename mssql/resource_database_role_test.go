@@ -3,6 +3,7 @@ package mssql
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -204,6 +205,107 @@ func TestAccDatabaseRole_Local_Basic_Remove_owner(t *testing.T) {
 	})
 }
 
+func TestAccDatabaseRole_Local_Permissions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRole(t, "local_test_perm", "login", map[string]interface{}{"role_name": "test_role_perm", "permissions": []map[string]string{{"permission": "VIEW DEFINITION"}, {"permission": "SELECT", "securable_type": "SCHEMA", "securable_name": "dbo"}}}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists("mssql_database_role.local_test_perm"),
+					resource.TestCheckResourceAttr("mssql_database_role.local_test_perm", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("mssql_database_role.local_test_perm", "permissions.*", map[string]string{"permission": "VIEW DEFINITION", "securable_type": "DATABASE"}),
+					resource.TestCheckTypeSetElemNestedAttrs("mssql_database_role.local_test_perm", "permissions.*", map[string]string{"permission": "SELECT", "securable_type": "SCHEMA", "securable_name": "dbo"}),
+				),
+			},
+			{
+				// Permissions granted outside of the role definition are revoked
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "GRANT INSERT ON SCHEMA::[dbo] TO [test_role_perm]"); err != nil {
+						t.Fatalf("unable to change permissions: %s", err)
+					}
+				},
+				Config: testAccCheckRole(t, "local_test_perm", "login", map[string]interface{}{"role_name": "test_role_perm", "permissions": []map[string]string{{"permission": "EXECUTE", "securable_type": "SCHEMA", "securable_name": "dbo"}}}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleExists("mssql_database_role.local_test_perm"),
+					resource.TestCheckResourceAttr("mssql_database_role.local_test_perm", "permissions.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("mssql_database_role.local_test_perm", "permissions.*", map[string]string{"permission": "EXECUTE", "securable_type": "SCHEMA", "securable_name": "dbo"}),
+				),
+			},
+			{
+				Config:      testAccCheckRole(t, "local_test_perm", "login", map[string]interface{}{"role_name": "test_role_perm", "permissions": []map[string]string{{"permission": "SELECT", "securable_type": "SCHEMA"}}}),
+				ExpectError: regexp.MustCompile("securable_name is required for permission SELECT on a SCHEMA"),
+			},
+			{
+				Config:      testAccCheckRole(t, "local_test_perm", "login", map[string]interface{}{"role_name": "test_role_perm", "permissions": []map[string]string{{"permission": "ALTER ANY USER", "securable_type": "SCHEMA", "securable_name": "dbo"}}}),
+				ExpectError: regexp.MustCompile(`permission \[ALTER ANY USER\] does not apply to SCHEMA, only to DATABASE`),
+			},
+		},
+	})
+}
+
+func TestAccDatabaseRole_Local_OnDestroyTransferOwnership(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccCheckRoleDestroy(state); err != nil {
+				return err
+			}
+			return testAccDropOwnedSchema("master", "test_role_transfer_schema", "dbo")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRole(t, "transfer_ownership", "login", map[string]interface{}{"role_name": "test_role_transfer", "on_destroy_transfer_ownership_to": "dbo"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_database_role.transfer_ownership", "on_destroy_transfer_ownership_to", "dbo"),
+					testAccCreateOwnedSchema("master", "test_role_transfer_schema", "test_role_transfer"),
+				),
+			},
+			{
+				Config:      testAccCheckRole(t, "transfer_ownership", "login", map[string]interface{}{"role_name": "test_role_transfer", "on_destroy_transfer_ownership_to": "dbo] TO [dbo"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("invalid SQL identifier"),
+			},
+		},
+	})
+}
+
+func TestAccDatabaseRole_Local_OnDestroyFailOnOwnedObjects(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckRole(t, "fail_on_owned", "login", map[string]interface{}{"role_name": "test_role_fail_on_owned", "on_destroy_fail_on_owned_objects": true}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_database_role.fail_on_owned", "on_destroy_fail_on_owned_objects", "true"),
+					testAccCreateOwnedSchema("master", "test_role_fail_on_owned_schema", "test_role_fail_on_owned"),
+				),
+			},
+			{
+				Config:      testAccCheckRole(t, "fail_on_owned", "login", map[string]interface{}{"role_name": "test_role_fail_on_owned", "on_destroy_fail_on_owned_objects": true}),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`owns 1 object\(s\)`),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccDropOwnedSchema("master", "test_role_fail_on_owned_schema", "test_role_fail_on_owned"); err != nil {
+						t.Fatalf("unable to drop schema: %s", err)
+					}
+				},
+				Config: testAccCheckRole(t, "fail_on_owned", "login", map[string]interface{}{"role_name": "test_role_fail_on_owned", "on_destroy_fail_on_owned_objects": true}),
+			},
+		},
+	})
+}
+
 func TestAccDatabaseRole_Azure_Basic_Update(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -335,6 +437,14 @@ func testAccCheckRole(t *testing.T, name string, login string, data map[string]i
 				{{ with .database }}database = "{{ . }}"{{ end }}
 				role_name = "{{ .role_name }}"
 				{{ with .owner_name }}owner_name = "{{ . }}"{{ end }}
+				{{ with .on_destroy_transfer_ownership_to }}on_destroy_transfer_ownership_to = "{{ . }}"{{ end }}
+				{{ with .on_destroy_fail_on_owned_objects }}on_destroy_fail_on_owned_objects = {{ . }}{{ end }}
+				{{ range .permissions }}
+				permissions {
+					{{ range $key, $value := . }}{{ $key }} = "{{ $value }}"
+					{{ end }}
+				}
+				{{ end }}
 				{{ if .username }}
 				depends_on = [mssql_user.{{ .name }}]
 				{{ end }}
@@ -2,6 +2,7 @@ package mssql
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
@@ -57,6 +58,8 @@ func resourceDatabaseSchema() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			onDestroyTransferOwnershipToProp: getOnDestroyTransferOwnershipSchema(),
+			onDestroyFailOnOwnedProp:         getOnDestroyFailOnOwnedSchema(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
@@ -71,7 +74,8 @@ type DatabaseSchemaConnector interface {
 	CreateDatabaseSchema(ctx context.Context, database string, schemaName string, ownerName string) error
 	GetDatabaseSchema(ctx context.Context, database, schemaName string) (*model.DatabaseSchema, error)
 	UpdateDatabaseSchema(ctx context.Context, database string, schemaName string, ownerName string) error
-	DeleteDatabaseSchema(ctx context.Context, database, schemaName string) error
+	DeleteDatabaseSchema(ctx context.Context, database, schemaName, newOwner string) error
+	GetSchemaObjects(ctx context.Context, database, schemaName string) ([]model.OwnedObject, error)
 	DatabaseExists(ctx context.Context, database string) (bool, error)
 }
 
@@ -162,7 +166,18 @@ func resourceDatabaseSchemaDelete(ctx context.Context, data *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	if err = connector.DeleteDatabaseSchema(ctx, database, schemaName); err != nil {
+	// A schema can only be dropped when it is empty, so fail before its ownership is transferred
+	if data.Get(onDestroyFailOnOwnedProp).(bool) {
+		objects, err := connector.GetSchemaObjects(ctx, database, schemaName)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read objects of schema [%s].[%s]", database, schemaName))
+		}
+		if len(objects) > 0 {
+			return ownedObjectsDiagnostics(fmt.Sprintf("schema [%s].[%s] contains %d object(s)", database, schemaName, len(objects)), objects)
+		}
+	}
+
+	if err = connector.DeleteDatabaseSchema(ctx, database, schemaName, data.Get(onDestroyTransferOwnershipToProp).(string)); err != nil {
 		return diag.FromErr(errors.Wrapf(err, "unable to delete schema [%s].[%s]", database, schemaName))
 	}
 
@@ -260,6 +275,9 @@ func resourceDatabaseSchemaImport(ctx context.Context, data *schema.ResourceData
 	if err = data.Set(ownerNameProp, sqlschema.OwnerName); err != nil {
 		return nil, err
 	}
+	if err = data.Set(onDestroyFailOnOwnedProp, false); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{data}, nil
 }
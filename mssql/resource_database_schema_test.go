@@ -3,6 +3,7 @@ package mssql
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -225,6 +226,39 @@ func TestAccDatabaseSchema_Azure_Basic_Update_owner(t *testing.T) {
 	})
 }
 
+func TestAccDatabaseSchema_Local_OnDestroyFailOnOwnedObjects(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckSchemaDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSchema(t, "fail_on_owned", "login", map[string]interface{}{"schema_name": "test_schema_fail_on_owned", "on_destroy_fail_on_owned_objects": true}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSchemaExists("mssql_database_schema.fail_on_owned"),
+					func(state *terraform.State) error {
+						return testAccExecuteLocalScript("master", "CREATE TABLE [test_schema_fail_on_owned].[test_table] (id int)")
+					},
+				),
+			},
+			{
+				Config:      testAccCheckSchema(t, "fail_on_owned", "login", map[string]interface{}{"schema_name": "test_schema_fail_on_owned", "on_destroy_fail_on_owned_objects": true}),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`OBJECT \[test_schema_fail_on_owned\]\.\[test_table\]`),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "DROP TABLE [test_schema_fail_on_owned].[test_table]"); err != nil {
+						t.Fatalf("unable to drop table: %s", err)
+					}
+				},
+				Config: testAccCheckSchema(t, "fail_on_owned", "login", map[string]interface{}{"schema_name": "test_schema_fail_on_owned", "on_destroy_fail_on_owned_objects": true}),
+			},
+		},
+	})
+}
+
 func testAccCheckSchema(t *testing.T, name string, login string, data map[string]interface{}) string {
 	text := `{{ if .login_name }}
 			resource "mssql_login" "{{ .name }}" {
@@ -262,6 +296,7 @@ func testAccCheckSchema(t *testing.T, name string, login string, data map[string
 				{{ with .database }}database = "{{ . }}"{{ end }}
 				schema_name = "{{ .schema_name }}"
 				{{ with .owner_name }}owner_name = "{{ . }}"{{ end }}
+				{{ with .on_destroy_fail_on_owned_objects }}on_destroy_fail_on_owned_objects = {{ . }}{{ end }}
 				{{ if .username }}
 				depends_on = [mssql_user.{{ .name }}]
 				{{ end }}
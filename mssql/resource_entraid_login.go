@@ -15,6 +15,7 @@ func resourceEntraIDLogin() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceEntraIDLoginCreate,
 		ReadContext:   resourceEntraIDLoginRead,
+		UpdateContext: resourceEntraIDLoginUpdate,
 		DeleteContext: resourceEntraIDLoginDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceEntraIDLoginImport,
@@ -46,16 +47,28 @@ func resourceEntraIDLogin() *schema.Resource {
 			},
 			defaultDatabaseProp: {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
 			defaultLanguageProp: {
 				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			enabledProp: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			isGroupProp: {
+				Type:     schema.TypeBool,
 				Computed: true,
 			},
 			principalIdProp: {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			onDestroyProp: getOnDestroySchema(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
@@ -67,8 +80,10 @@ func resourceEntraIDLogin() *schema.Resource {
 }
 
 type EntraIDLoginConnector interface {
-	CreateEntraIDLogin(ctx context.Context, name, objectId string) error
+	LoginSessionConnector
+	CreateEntraIDLogin(ctx context.Context, name, objectId, defaultDatabase, defaultLanguage string, enabled bool) error
 	GetEntraIDLogin(ctx context.Context, name string) (*model.EntraIDLogin, error)
+	UpdateEntraIDLogin(ctx context.Context, name, defaultDatabase, defaultLanguage string, enabled bool) error
 	DeleteEntraIDLogin(ctx context.Context, name string) error
 }
 
@@ -78,13 +93,16 @@ func resourceEntraIDLoginCreate(ctx context.Context, data *schema.ResourceData,
 
 	loginName := data.Get(loginNameProp).(string)
 	objectId := data.Get(objectIdProp).(string)
+	defaultDatabase := data.Get(defaultDatabaseProp).(string)
+	defaultLanguage := data.Get(defaultLanguageProp).(string)
+	enabled := data.Get(enabledProp).(bool)
 
 	connector, err := getEntraIDLoginConnector(meta, data)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = connector.CreateEntraIDLogin(ctx, loginName, objectId); err != nil {
+	if err = connector.CreateEntraIDLogin(ctx, loginName, objectId, defaultDatabase, defaultLanguage, enabled); err != nil {
 		return diag.FromErr(errors.Wrapf(err, "unable to create EntraID Login [%s]", loginName))
 	}
 
@@ -126,11 +144,65 @@ func resourceEntraIDLoginRead(ctx context.Context, data *schema.ResourceData, me
 		if err = data.Set(principalIdProp, EntraIDLogin.PrincipalID); err != nil {
 			return diag.FromErr(err)
 		}
+		if err = data.Set(enabledProp, !EntraIDLogin.IsDisabled); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(isGroupProp, EntraIDLogin.IsGroup); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return nil
 }
 
+func resourceEntraIDLoginUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "EntraIDLogin", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	loginName := data.Get(loginNameProp).(string)
+	enabled := data.Get(enabledProp).(bool)
+
+	// The defaults are only sent when changed, as they are read back from the server even when not configured
+	var defaultDatabase, defaultLanguage string
+	if data.HasChange(defaultDatabaseProp) {
+		defaultDatabase = data.Get(defaultDatabaseProp).(string)
+	}
+	if data.HasChange(defaultLanguageProp) {
+		defaultLanguage = data.Get(defaultLanguageProp).(string)
+	}
+
+	// Store old values for all properties that might change
+	oldValues := make(map[string]interface{})
+	for _, prop := range []string{defaultDatabaseProp, defaultLanguageProp, enabledProp} {
+		if data.HasChange(prop) {
+			oldValue, _ := data.GetChange(prop)
+			oldValues[prop] = oldValue
+		}
+	}
+
+	// on_destroy only matters when the login is destroyed, so changing it alone does not touch the server.
+	if len(oldValues) > 0 {
+		connector, err := getEntraIDLoginConnector(meta, data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err = connector.UpdateEntraIDLogin(ctx, loginName, defaultDatabase, defaultLanguage, enabled); err != nil {
+			// If update fails, revert all changed values in the state
+			for prop, oldValue := range oldValues {
+				if err := data.Set(prop, oldValue); err != nil {
+					logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
+				}
+			}
+			return diag.FromErr(errors.Wrapf(err, "unable to update EntraID Login [%s]", loginName))
+		}
+
+		logger.Info().Msgf("updated EntraID Login [%s]", loginName)
+	}
+
+	return resourceEntraIDLoginRead(ctx, data, meta)
+}
+
 func resourceEntraIDLoginDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	logger := loggerFromMeta(meta, "EntraIDLogin", "delete")
 	logger.Debug().Msgf("Delete %s", data.Id())
@@ -142,11 +214,13 @@ func resourceEntraIDLoginDelete(ctx context.Context, data *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	if err = connector.DeleteEntraIDLogin(ctx, loginName); err != nil {
-		return diag.FromErr(errors.Wrapf(err, "unable to delete EntraID Login [%s]", loginName))
+	if diags := destroyLogin(ctx, data, connector, loginName, func() error {
+		return connector.DeleteEntraIDLogin(ctx, loginName)
+	}); diags.HasError() {
+		return diags
 	}
 
-	logger.Info().Msgf("deleted EntraID Login [%s]", loginName)
+	logger.Info().Msgf("deleted EntraID Login [%s] with %s = %s", loginName, onDestroyProp, data.Get(onDestroyProp))
 
 	data.SetId("")
 
@@ -172,6 +246,9 @@ func resourceEntraIDLoginImport(ctx context.Context, data *schema.ResourceData,
 	if err = data.Set(loginNameProp, parts[2]); err != nil {
 		return nil, err
 	}
+	if err = data.Set(onDestroyProp, onDestroyDefault); err != nil {
+		return nil, err
+	}
 
 	data.SetId(getLoginID(data))
 
@@ -203,6 +280,12 @@ func resourceEntraIDLoginImport(ctx context.Context, data *schema.ResourceData,
 	if err = data.Set(principalIdProp, EntraIDLogin.PrincipalID); err != nil {
 		return nil, err
 	}
+	if err = data.Set(enabledProp, !EntraIDLogin.IsDisabled); err != nil {
+		return nil, err
+	}
+	if err = data.Set(isGroupProp, EntraIDLogin.IsGroup); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{data}, nil
 }
@@ -22,6 +22,33 @@ func TestAccEntraIDLogin_Azure_Basic(t *testing.T) {
 					testAccCheckEntraIDLoginExists("mssql_entraid_login.basic"),
 					resource.TestCheckResourceAttr("mssql_entraid_login.basic", "login_name", clientUser),
 					resource.TestCheckResourceAttrSet("mssql_entraid_login.basic", "principal_id"),
+					resource.TestCheckResourceAttr("mssql_entraid_login.basic", "enabled", "true"),
+					resource.TestCheckResourceAttr("mssql_entraid_login.basic", "is_group", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEntraIDLogin_Azure_UpdateEnabled(t *testing.T) {
+	clientUser := os.Getenv("TF_ACC_AZURE_USER_CLIENT_USER")
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckEntraIDLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckEntraIDLogin(t, "update", "azure", map[string]interface{}{"login_name": clientUser, "enabled": "false"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEntraIDLoginExists("mssql_entraid_login.update", Check{"enabled", "==", false}),
+					resource.TestCheckResourceAttr("mssql_entraid_login.update", "enabled", "false"),
+				),
+			},
+			{
+				Config: testAccCheckEntraIDLogin(t, "update", "azure", map[string]interface{}{"login_name": clientUser, "enabled": "true"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEntraIDLoginExists("mssql_entraid_login.update", Check{"enabled", "==", true}),
+					resource.TestCheckResourceAttr("mssql_entraid_login.update", "enabled", "true"),
 				),
 			},
 		},
@@ -36,6 +63,9 @@ func testAccCheckEntraIDLogin(t *testing.T, name string, login string, data map[
 				}
 				login_name = "{{ .login_name }}"
 				{{ with .object_id }}object_id = "{{ . }}"{{ end }}
+				{{ with .default_database }}default_database = "{{ . }}"{{ end }}
+				{{ with .default_language }}default_language = "{{ . }}"{{ end }}
+				{{ with .enabled }}enabled = {{ . }}{{ end }}
 			}`
 
 	data["name"] = name
@@ -111,14 +141,16 @@ func testAccCheckEntraIDLoginExists(resource string, checks ...Check) resource.T
 				actual = login.DefaultDatabase
 			case "default_language":
 				actual = login.DefaultLanguage
+			case "enabled":
+				actual = !login.IsDisabled
 			default:
 				return fmt.Errorf("unknown property %s", check.name)
 			}
 			if (check.op == "" || check.op == "==") && check.expected != actual {
-				return fmt.Errorf("expected %s == %s, got %s", check.name, check.expected, actual)
+				return fmt.Errorf("expected %s == %v, got %v", check.name, check.expected, actual)
 			}
 			if check.op == "!=" && check.expected == actual {
-				return fmt.Errorf("expected %s != %s, got %s", check.name, check.expected, actual)
+				return fmt.Errorf("expected %s != %v, got %v", check.name, check.expected, actual)
 			}
 		}
 		return nil
@@ -7,6 +7,7 @@ import (
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
 	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
 )
@@ -37,9 +38,16 @@ func resourceLogin() *schema.Resource {
 			},
 			passwordProp: {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				Sensitive:    true,
-				ValidateFunc: validate.SQLIdentifierPassword,
+				ExactlyOneOf: []string{passwordProp, generatePasswordProp},
+				ValidateFunc: validate.SQLPasswordLength,
+			},
+			generatePasswordProp: getGeneratePasswordSchema(),
+			generatedPasswordProp: {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 			sidStrProp: {
 				Type:     schema.TypeString,
@@ -66,7 +74,9 @@ func resourceLogin() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			onDestroyProp: getOnDestroySchema(),
 		},
+		CustomizeDiff: customdiff.All(customizeDiffGeneratedPassword, customizeDiffPasswordPolicy(loginNameProp)),
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
 			Read:   defaultTimeout,
@@ -77,6 +87,7 @@ func resourceLogin() *schema.Resource {
 }
 
 type LoginConnector interface {
+	LoginSessionConnector
 	CreateLogin(ctx context.Context, name, password, sid, defaultDatabase, defaultLanguage string) error
 	GetLogin(ctx context.Context, name string) (*model.Login, error)
 	UpdateLogin(ctx context.Context, name, password, defaultDatabase, defaultLanguage string) error
@@ -88,11 +99,15 @@ func resourceLoginCreate(ctx context.Context, data *schema.ResourceData, meta in
 	logger.Debug().Msgf("Create %s", getLoginID(data))
 
 	loginName := data.Get(loginNameProp).(string)
-	password := data.Get(passwordProp).(string)
 	sid := data.Get(sidStrProp).(string)
 	defaultDatabase := data.Get(defaultDatabaseProp).(string)
 	defaultLanguage := data.Get(defaultLanguageProp).(string)
 
+	password, err := resolvePassword(data, meta, loginName, true)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to generate password for login [%s]", loginName))
+	}
+
 	connector, err := getLoginConnector(meta, data)
 	if err != nil {
 		return diag.FromErr(err)
@@ -150,32 +165,40 @@ func resourceLoginUpdate(ctx context.Context, data *schema.ResourceData, meta in
 	logger.Debug().Msgf("Update %s", data.Id())
 
 	loginName := data.Get(loginNameProp).(string)
-	password := data.Get(passwordProp).(string)
 	defaultDatabase := data.Get(defaultDatabaseProp).(string)
 	defaultLanguage := data.Get(defaultLanguageProp).(string)
 
 	// Store old values for all properties that might change
 	oldValues := make(map[string]interface{})
-	for _, prop := range []string{passwordProp, defaultDatabaseProp, defaultLanguageProp} {
+	for _, prop := range []string{passwordProp, generatePasswordProp, defaultDatabaseProp, defaultLanguageProp} {
 		if data.HasChange(prop) {
 			oldValue, _ := data.GetChange(prop)
 			oldValues[prop] = oldValue
 		}
 	}
+	oldValues[generatedPasswordProp], _ = data.GetChange(generatedPasswordProp)
+
+	password, err := resolvePassword(data, meta, loginName, data.HasChange(generatePasswordProp))
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to generate password for login [%s]", loginName))
+	}
 
 	connector, err := getLoginConnector(meta, data)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = connector.UpdateLogin(ctx, loginName, password, defaultDatabase, defaultLanguage); err != nil {
-		// If update fails, revert all changed values in the state
-		for prop, oldValue := range oldValues {
-			if err := data.Set(prop, oldValue); err != nil {
-				logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
+	// on_destroy only matters when the login is destroyed, so changing it alone does not touch the server.
+	if data.HasChanges(passwordProp, generatePasswordProp, defaultDatabaseProp, defaultLanguageProp) {
+		if err = connector.UpdateLogin(ctx, loginName, password, defaultDatabase, defaultLanguage); err != nil {
+			// If update fails, revert all changed values in the state
+			for prop, oldValue := range oldValues {
+				if err := data.Set(prop, oldValue); err != nil {
+					logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
+				}
 			}
+			return diag.FromErr(errors.Wrapf(err, "unable to update login [%s]", loginName))
 		}
-		return diag.FromErr(errors.Wrapf(err, "unable to update login [%s]", loginName))
 	}
 
 	data.SetId(getLoginID(data))
@@ -196,11 +219,13 @@ func resourceLoginDelete(ctx context.Context, data *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
-	if err = connector.DeleteLogin(ctx, loginName); err != nil {
-		return diag.FromErr(errors.Wrapf(err, "unable to delete login [%s]", loginName))
+	if diags := destroyLogin(ctx, data, connector, loginName, func() error {
+		return connector.DeleteLogin(ctx, loginName)
+	}); diags.HasError() {
+		return diags
 	}
 
-	logger.Info().Msgf("deleted login [%s]", loginName)
+	logger.Info().Msgf("deleted login [%s] with %s = %s", loginName, onDestroyProp, data.Get(onDestroyProp))
 
 	// d.SetId("") is automatically called assuming delete returns no errors, but it is added here for explicitness.
 	data.SetId("")
@@ -227,6 +252,9 @@ func resourceLoginImport(ctx context.Context, data *schema.ResourceData, meta in
 	if err = data.Set(loginNameProp, parts[2]); err != nil {
 		return nil, err
 	}
+	if err = data.Set(onDestroyProp, onDestroyDefault); err != nil {
+		return nil, err
+	}
 
 	data.SetId(getLoginID(data))
 
@@ -249,6 +249,95 @@ func TestAccLogin_Local_UpdateDefaultLanguage(t *testing.T) {
 		}})
 }
 
+func TestAccLogin_Local_GeneratePassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogin(t, "test_generate", "login", map[string]interface{}{"login_name": "login_generate", "generate_password": "{\n length = 24\n keepers = { rotation = \"1\" }\n }"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoginExists("mssql_login.test_generate"),
+					testAccCheckLoginWorks("mssql_login.test_generate"),
+					resource.TestCheckNoResourceAttr("mssql_login.test_generate", "password"),
+					resource.TestCheckResourceAttr("mssql_login.test_generate", "generate_password.#", "1"),
+					resource.TestCheckResourceAttr("mssql_login.test_generate", "generate_password.0.length", "24"),
+					resource.TestMatchResourceAttr("mssql_login.test_generate", "generated_password", regexp.MustCompile("^.{24}$")),
+				),
+			},
+			{
+				Config: testAccCheckLogin(t, "test_generate", "login", map[string]interface{}{"login_name": "login_generate", "generate_password": "{\n length = 24\n keepers = { rotation = \"2\" }\n }"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLoginExists("mssql_login.test_generate"),
+					testAccCheckLoginWorks("mssql_login.test_generate"),
+					resource.TestCheckResourceAttr("mssql_login.test_generate", "generate_password.0.keepers.rotation", "2"),
+					resource.TestMatchResourceAttr("mssql_login.test_generate", "generated_password", regexp.MustCompile("^.{24}$")),
+				),
+			},
+		}})
+}
+
+func TestAccLogin_Local_GeneratePassword_Classes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckLogin(t, "test_generate", "login", map[string]interface{}{"login_name": "login_generate", "generate_password": "{\n upper = false\n special = false\n }"}),
+				ExpectError: regexp.MustCompile("must enable at least 3 of the character classes"),
+			},
+		},
+	})
+}
+
+func TestAccLogin_Local_OnDestroy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogin(t, "test_on_destroy", "login", map[string]interface{}{"login_name": "login_on_destroy", "password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_login.test_on_destroy", "on_destroy", "kill_sessions"),
+					testAccCheckLoginExists("mssql_login.test_on_destroy"),
+				),
+			},
+			{
+				Config: testAccCheckLogin(t, "test_on_destroy", "login", map[string]interface{}{"login_name": "login_on_destroy", "password": "valueIsH8kd$¡", "on_destroy": "fail_if_connected"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_login.test_on_destroy", "on_destroy", "fail_if_connected"),
+					testAccCheckLoginExists("mssql_login.test_on_destroy"),
+					testAccCheckLoginWorks("mssql_login.test_on_destroy"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLogin_Local_OnDestroyDisableOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckLoginDisabledOnDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogin(t, "test_disable_only", "login", map[string]interface{}{"login_name": "login_disable_only", "password": "valueIsH8kd$¡", "on_destroy": "disable_only"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_login.test_disable_only", "on_destroy", "disable_only"),
+					testAccCheckLoginExists("mssql_login.test_disable_only"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccLogin_Azure_UpdateLoginName(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -302,10 +391,12 @@ func testAccCheckLogin(t *testing.T, name string, login string, data map[string]
 					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
 				}
 				login_name = "{{ .login_name }}"
-				password   = "{{ .password }}"
+				{{ with .password }}password = "{{ . }}"{{ end }}
+				{{ with .generate_password }}generate_password {{ . }}{{ end }}
 				{{ with .sid }}sid = "{{ . }}"{{ end }}
 				{{ with .default_database }}default_database = "{{ . }}"{{ end }}
 				{{ with .default_language }}default_language = "{{ . }}"{{ end }}
+				{{ with .on_destroy }}on_destroy = "{{ . }}"{{ end }}
 			}`
 
 	data["name"] = name
@@ -348,6 +439,34 @@ func testAccCheckLoginDestroy(state *terraform.State) error {
 	return nil
 }
 
+// testAccCheckLoginDisabledOnDestroy checks that logins destroyed with on_destroy = "disable_only" still exist and
+// drops them afterwards.
+func testAccCheckLoginDisabledOnDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_login" {
+			continue
+		}
+
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		loginName := rs.Primary.Attributes[loginNameProp]
+		login, err := connector.GetLogin(loginName)
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if login == nil {
+			return fmt.Errorf("login [%s] was dropped instead of disabled", loginName)
+		}
+		if err = connector.DataBaseExecuteScript("master", fmt.Sprintf("DROP LOGIN [%s]", loginName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func testAccCheckLoginExists(resource string, checks ...Check) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
@@ -0,0 +1,268 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceMultiDatabasePermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMultiDatabasePermissionsCreate,
+		ReadContext:   resourceMultiDatabasePermissionsRead,
+		UpdateContext: resourceMultiDatabasePermissionsUpdate,
+		DeleteContext: resourceMultiDatabasePermissionsDelete,
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			databasesProp: getDatabasesSelectorSchema(),
+			usernameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			permissionsProp: {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.SQLIdentifierPermission,
+				},
+			},
+			databaseStatesProp: getDatabaseStatesSchema(),
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffDatabaseStates,
+			customizeDiffPermissionCatalog(databasePermissionClass, permissionsProp),
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Update: defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type MultiDatabasePermissionsConnector interface {
+	GetDatabasePermissions(ctx context.Context, database string, username string) (*model.DatabasePermissions, error)
+	UpdateDatabasePermissions(ctx context.Context, database string, username string, permissions []string, changeType string) error
+	DatabasesConnector
+}
+
+func resourceMultiDatabasePermissionsCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_permissions", "create")
+	logger.Debug().Msgf("Create %s", getMultiDatabasePermissionsID(data))
+
+	if diags := applyMultiDatabasePermissions(ctx, data, meta); diags.HasError() {
+		return diags
+	}
+
+	data.SetId(getMultiDatabasePermissionsID(data))
+
+	return resourceMultiDatabasePermissionsRead(ctx, data, meta)
+}
+
+func resourceMultiDatabasePermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_permissions", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	username := data.Get(usernameProp).(string)
+	permissions := toStringSlice(data.Get(permissionsProp).(*schema.Set).List())
+
+	connector, err := getMultiDatabasePermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databases, unavailable, err := resolveDatabases(ctx, data, connector)
+	if err != nil {
+		return diag.FromErr(errors.Wrap(err, "unable to resolve databases"))
+	}
+
+	states := make(map[string]string, len(databases))
+	for _, database := range databases {
+		current, err := connector.GetDatabasePermissions(ctx, database, username)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read permissions for user [%s] on database [%s]", username, database))
+		}
+		if current == nil {
+			states[database] = databaseStateMissing
+			continue
+		}
+		states[database] = databaseStateInSync
+		if missing := missingPermissions(permissions, grantedPermissions(current)); len(missing) > 0 {
+			logger.Info().Msgf("permissions [%s] of user [%s] on database [%s] are missing", strings.Join(missing, ", "), username, database)
+			states[database] = databaseStateDrifted
+		}
+	}
+
+	if err = setDatabaseStates(data, states, unavailable); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMultiDatabasePermissionsUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_permissions", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	if diags := applyMultiDatabasePermissions(ctx, data, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceMultiDatabasePermissionsRead(ctx, data, meta)
+}
+
+func resourceMultiDatabasePermissionsDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_permissions", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	username := data.Get(usernameProp).(string)
+	permissions := toStringSlice(data.Get(permissionsProp).(*schema.Set).List())
+
+	connector, err := getMultiDatabasePermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databases, err := stateDatabases(ctx, data, connector)
+	if err != nil {
+		return diag.FromErr(errors.Wrap(err, "unable to list databases"))
+	}
+	for _, database := range databases {
+		if err = revokeMultiDatabasePermissions(ctx, connector, database, username, permissions); err != nil {
+			return diag.FromErr(err)
+		}
+		logger.Info().Msgf("revoked permissions of user [%s] on database [%s]", username, database)
+	}
+
+	data.SetId("")
+
+	return nil
+}
+
+// applyMultiDatabasePermissions grants the missing permissions in every selected database, revokes the permissions
+// removed from the configuration, and revokes every managed permission from the databases that are no longer selected.
+func applyMultiDatabasePermissions(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_permissions", "apply")
+
+	username := data.Get(usernameProp).(string)
+	oldVal, newVal := data.GetChange(permissionsProp)
+	oldPermissions := toStringSlice(oldVal.(*schema.Set).List())
+	permissions := toStringSlice(newVal.(*schema.Set).List())
+	_, removedPermissions := stringSetDiff(oldVal.(*schema.Set), newVal.(*schema.Set))
+
+	connector, err := getMultiDatabasePermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databases, err := resolveDatabasesForApply(ctx, data, connector)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	removed, err := removedDatabases(ctx, data, connector, databases)
+	if err != nil {
+		return diag.FromErr(errors.Wrap(err, "unable to list databases"))
+	}
+
+	for _, database := range databases {
+		current, err := connector.GetDatabasePermissions(ctx, database, username)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read permissions for user [%s] on database [%s]", username, database))
+		}
+		if current == nil {
+			return diag.Errorf("user [%s] does not exist in database [%s]", username, database)
+		}
+		if toGrant := missingPermissions(permissions, grantedPermissions(current)); len(toGrant) > 0 {
+			if err = connector.UpdateDatabasePermissions(ctx, database, username, toGrant, "GRANT"); err != nil {
+				return diag.FromErr(errors.Wrapf(err, "unable to grant permissions for user [%s] on database [%s]", username, database))
+			}
+			logger.Info().Msgf("granted permissions [%s] to user [%s] on database [%s]", strings.Join(toGrant, ", "), username, database)
+		}
+		if err = revokeMultiDatabasePermissions(ctx, connector, database, username, removedPermissions); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, database := range removed {
+		if err = revokeMultiDatabasePermissions(ctx, connector, database, username, oldPermissions); err != nil {
+			return diag.FromErr(err)
+		}
+		logger.Info().Msgf("revoked permissions of user [%s] on database [%s]", username, database)
+	}
+
+	return nil
+}
+
+// revokeMultiDatabasePermissions revokes the given permissions that are currently granted to the user.
+func revokeMultiDatabasePermissions(ctx context.Context, connector MultiDatabasePermissionsConnector, database, username string, permissions []string) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+	current, err := connector.GetDatabasePermissions(ctx, database, username)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read permissions for user [%s] on database [%s]", username, database)
+	}
+	if current == nil {
+		return nil
+	}
+	var toRevoke []string
+	for _, permission := range permissions {
+		if len(missingPermissions([]string{permission}, grantedPermissions(current))) == 0 {
+			toRevoke = append(toRevoke, permission)
+		}
+	}
+	if len(toRevoke) == 0 {
+		return nil
+	}
+	if err = connector.UpdateDatabasePermissions(ctx, database, username, toRevoke, "REVOKE"); err != nil {
+		return errors.Wrapf(err, "unable to revoke permissions for user [%s] on database [%s]", username, database)
+	}
+	return nil
+}
+
+// grantedPermissions returns the permissions granted to the user, with or without GRANT OPTION.
+func grantedPermissions(permissions *model.DatabasePermissions) []string {
+	return append(append([]string{}, permissions.Permissions...), permissions.WithGrantOptionPermissions...)
+}
+
+// missingPermissions returns the wanted permissions that are not in current, ignoring case.
+func missingPermissions(wanted, current []string) []string {
+	granted := make(map[string]struct{}, len(current))
+	for _, permission := range current {
+		granted[strings.ToUpper(permission)] = struct{}{}
+	}
+	var missing []string
+	for _, permission := range wanted {
+		if _, ok := granted[strings.ToUpper(permission)]; !ok {
+			missing = append(missing, permission)
+		}
+	}
+	return missing
+}
+
+func getMultiDatabasePermissionsConnector(meta interface{}, data *schema.ResourceData) (MultiDatabasePermissionsConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(MultiDatabasePermissionsConnector), nil
+}
@@ -0,0 +1,161 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMultiDatabasePermissions_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckMultiDatabasePermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMultiDatabasePermissions(t, "basic", "login", map[string]interface{}{"username": "multi_db_perm_user", "databases": "[\"master\", \"msdb\"]", "permissions": "[\"EXECUTE\", \"VIEW DEFINITION\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMultiDatabasePermissionsExist("mssql_multi_database_permissions.basic"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "username", "multi_db_perm_user"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "database_states.%", "2"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "database_states.master", "in_sync"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "database_states.msdb", "in_sync"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "server.#", "1"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "server.0.host", "localhost"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "server.0.login.#", "1"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "server.0.login.0.username", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "server.0.azure_login.#", "0"),
+				),
+			},
+			{
+				// The permission revoked outside of Terraform is granted again
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("msdb", "REVOKE EXECUTE FROM [multi_db_perm_user]"); err != nil {
+						t.Fatalf("unable to revoke permission: %s", err)
+					}
+				},
+				Config: testAccCheckMultiDatabasePermissions(t, "basic", "login", map[string]interface{}{"username": "multi_db_perm_user", "databases": "[\"master\", \"msdb\"]", "permissions": "[\"EXECUTE\", \"VIEW DEFINITION\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMultiDatabasePermissionsExist("mssql_multi_database_permissions.basic"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "database_states.msdb", "in_sync"),
+				),
+			},
+			{
+				Config: testAccCheckMultiDatabasePermissions(t, "basic", "login", map[string]interface{}{"username": "multi_db_perm_user", "databases": "[\"master\", \"msdb\"]", "permissions": "[\"EXECUTE\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMultiDatabasePermissionsExist("mssql_multi_database_permissions.basic"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_multi_database_permissions.basic", "permissions.0", "EXECUTE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMultiDatabasePermissions(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_login" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				login_name = "{{ .username }}"
+				password   = "valueIsH8kd$¡"
+			}
+			resource "mssql_multi_database_user" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				databases {
+					names = {{ .databases }}
+				}
+				username   = "{{ .username }}"
+				login_name = mssql_login.{{ .name }}.login_name
+			}
+			resource "mssql_multi_database_permissions" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				databases {
+					names = {{ .databases }}
+				}
+				username    = mssql_multi_database_user.{{ .name }}.username
+				permissions = {{ .permissions }}
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckMultiDatabasePermissionsDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_multi_database_permissions" {
+			continue
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		for _, database := range getDatabaseStatesFromState(rs.Primary.Attributes) {
+			permissions, err := connector.GetDatabasePermissions(database, rs.Primary.Attributes["username"])
+			if err != nil {
+				return fmt.Errorf("expected no error, got %s", err)
+			}
+			if permissions != nil && len(permissions.Permissions) > 0 {
+				return fmt.Errorf("permissions still exist in database [%s]", database)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccCheckMultiDatabasePermissionsExist(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_multi_database_permissions" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_multi_database_permissions", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		expected := rs.Primary.Attributes["permissions.#"]
+		for _, database := range getDatabaseStatesFromState(rs.Primary.Attributes) {
+			permissions, err := connector.GetDatabasePermissions(database, rs.Primary.Attributes["username"])
+			if err != nil {
+				return fmt.Errorf("expected no error, got %s", err)
+			}
+			if actual := fmt.Sprint(len(permissions.Permissions)); actual != expected {
+				return fmt.Errorf("expected %s permissions in database [%s], got %s", expected, database, actual)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,249 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+)
+
+func resourceMultiDatabaseUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMultiDatabaseUserCreate,
+		ReadContext:   resourceMultiDatabaseUserRead,
+		UpdateContext: resourceMultiDatabaseUserUpdate,
+		DeleteContext: resourceMultiDatabaseUserDelete,
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			databasesProp: getDatabasesSelectorSchema(),
+			usernameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			loginNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			defaultSchemaProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultDboPropDefault,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			rolesProp: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			databaseStatesProp: getDatabaseStatesSchema(),
+		},
+		CustomizeDiff: customizeDiffDatabaseStates,
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Update: defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type MultiDatabaseUserConnector interface {
+	CreateUser(ctx context.Context, database string, user *model.User) error
+	GetUser(ctx context.Context, database, username string) (*model.User, error)
+	GetUserDirectRoles(ctx context.Context, database, username string) ([]string, error)
+	UpdateUser(ctx context.Context, database string, user *model.User) error
+	DeleteUser(ctx context.Context, database, username string) error
+	DatabasesConnector
+}
+
+func resourceMultiDatabaseUserCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_user", "create")
+	logger.Debug().Msgf("Create %s", getMultiDatabaseUserID(data))
+
+	if diags := applyMultiDatabaseUser(ctx, data, meta); diags.HasError() {
+		return diags
+	}
+
+	data.SetId(getMultiDatabaseUserID(data))
+
+	return resourceMultiDatabaseUserRead(ctx, data, meta)
+}
+
+func resourceMultiDatabaseUserRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_user", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	username := data.Get(usernameProp).(string)
+	loginName := data.Get(loginNameProp).(string)
+	defaultSchema := data.Get(defaultSchemaProp).(string)
+	roles := toStringSlice(data.Get(rolesProp).(*schema.Set).List())
+
+	connector, err := getMultiDatabaseUserConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databases, unavailable, err := resolveDatabases(ctx, data, connector)
+	if err != nil {
+		return diag.FromErr(errors.Wrap(err, "unable to resolve databases"))
+	}
+
+	states := make(map[string]string, len(databases))
+	for _, database := range databases {
+		user, err := connector.GetUser(ctx, database, username)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read user [%s].[%s]", database, username))
+		}
+		if user == nil {
+			states[database] = databaseStateMissing
+			continue
+		}
+		// user.Roles includes the roles inherited through nested memberships, only direct memberships are managed
+		directRoles, err := connector.GetUserDirectRoles(ctx, database, username)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read roles of user [%s].[%s]", database, username))
+		}
+		switch {
+		case !strings.EqualFold(user.LoginName, loginName) ||
+			!strings.EqualFold(user.DefaultSchema, defaultSchema) ||
+			!equalFoldSets(directRoles, roles):
+			logger.Info().Msgf("user [%s].[%s] has drifted", database, username)
+			states[database] = databaseStateDrifted
+		default:
+			states[database] = databaseStateInSync
+		}
+	}
+
+	if err = setDatabaseStates(data, states, unavailable); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMultiDatabaseUserUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_user", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	if diags := applyMultiDatabaseUser(ctx, data, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceMultiDatabaseUserRead(ctx, data, meta)
+}
+
+func resourceMultiDatabaseUserDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_user", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	username := data.Get(usernameProp).(string)
+
+	connector, err := getMultiDatabaseUserConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databases, err := stateDatabases(ctx, data, connector)
+	if err != nil {
+		return diag.FromErr(errors.Wrap(err, "unable to list databases"))
+	}
+	for _, database := range databases {
+		if err = connector.DeleteUser(ctx, database, username); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to delete user [%s].[%s]", database, username))
+		}
+		logger.Info().Msgf("deleted user [%s].[%s]", database, username)
+	}
+
+	data.SetId("")
+
+	return nil
+}
+
+// applyMultiDatabaseUser creates or updates the user in every selected database, and drops it from the databases that
+// are no longer selected.
+func applyMultiDatabaseUser(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "multi_database_user", "apply")
+
+	username := data.Get(usernameProp).(string)
+	loginName := data.Get(loginNameProp).(string)
+	defaultSchema := data.Get(defaultSchemaProp).(string)
+	roles := toStringSlice(data.Get(rolesProp).(*schema.Set).List())
+
+	connector, err := getMultiDatabaseUserConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databases, err := resolveDatabasesForApply(ctx, data, connector)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	removed, err := removedDatabases(ctx, data, connector, databases)
+	if err != nil {
+		return diag.FromErr(errors.Wrap(err, "unable to list databases"))
+	}
+
+	for _, database := range databases {
+		current, err := connector.GetUser(ctx, database, username)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read user [%s].[%s]", database, username))
+		}
+		user := &model.User{
+			Username:      username,
+			LoginName:     loginName,
+			DefaultSchema: defaultSchema,
+			Roles:         roles,
+		}
+		if current == nil {
+			if err = connector.CreateUser(ctx, database, user); err != nil {
+				return diag.FromErr(errors.Wrapf(err, "unable to create user [%s].[%s]", database, username))
+			}
+			logger.Info().Msgf("created user [%s].[%s]", database, username)
+			continue
+		}
+		// Only remap the user if it is mapped to another login
+		if strings.EqualFold(current.LoginName, loginName) {
+			user.LoginName = ""
+		}
+		if err = connector.UpdateUser(ctx, database, user); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to update user [%s].[%s]", database, username))
+		}
+		logger.Info().Msgf("updated user [%s].[%s]", database, username)
+	}
+
+	for _, database := range removed {
+		if err = connector.DeleteUser(ctx, database, username); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to delete user [%s].[%s]", database, username))
+		}
+		logger.Info().Msgf("deleted user [%s].[%s]", database, username)
+	}
+
+	return nil
+}
+
+func getMultiDatabaseUserConnector(meta interface{}, data *schema.ResourceData) (MultiDatabaseUserConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(MultiDatabaseUserConnector), nil
+}
@@ -0,0 +1,236 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMultiDatabaseUser_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckMultiDatabaseUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMultiDatabaseUser(t, "basic", "login", map[string]interface{}{"username": "multi_db_user", "login_name": "multi_db_login", "login_password": "valueIsH8kd$¡", "databases": "[\"master\", \"msdb\"]", "roles": "[\"db_datareader\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMultiDatabaseUserExists("mssql_multi_database_user.basic"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "username", "multi_db_user"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "login_name", "multi_db_login"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "default_schema", "dbo"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "roles.#", "1"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "databases.0.names.#", "2"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "database_states.%", "2"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "database_states.master", "in_sync"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "database_states.msdb", "in_sync"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "server.#", "1"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "server.0.host", "localhost"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "server.0.login.#", "1"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "server.0.login.0.username", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "server.0.azure_login.#", "0"),
+				),
+			},
+			{
+				// The user is recreated in the database it was dropped from outside of Terraform
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("msdb", "DROP USER IF EXISTS [multi_db_user]"); err != nil {
+						t.Fatalf("unable to drop user: %s", err)
+					}
+				},
+				Config: testAccCheckMultiDatabaseUser(t, "basic", "login", map[string]interface{}{"username": "multi_db_user", "login_name": "multi_db_login", "login_password": "valueIsH8kd$¡", "databases": "[\"master\", \"msdb\"]", "roles": "[\"db_datareader\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMultiDatabaseUserExists("mssql_multi_database_user.basic"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "database_states.msdb", "in_sync"),
+				),
+			},
+			{
+				// The user is dropped from the database that is no longer selected
+				Config: testAccCheckMultiDatabaseUser(t, "basic", "login", map[string]interface{}{"username": "multi_db_user", "login_name": "multi_db_login", "login_password": "valueIsH8kd$¡", "databases": "[\"master\"]", "roles": "[\"db_datareader\", \"db_datawriter\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMultiDatabaseUserExists("mssql_multi_database_user.basic"),
+					testAccCheckMultiDatabaseUserNotExists("msdb", "multi_db_user"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "roles.#", "2"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "database_states.%", "1"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.basic", "database_states.master", "in_sync"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMultiDatabaseUser_Local_NestedRoles(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccExecuteLocalScript("master", "DROP ROLE IF EXISTS [multi_db_nested]"); err != nil {
+				return err
+			}
+			return testAccCheckMultiDatabaseUserDestroy(state)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Roles inherited through nested memberships are not reported as drift
+				PreConfig: func() {
+					script := "CREATE ROLE [multi_db_nested]; ALTER ROLE [db_datareader] ADD MEMBER [multi_db_nested]"
+					if err := testAccExecuteLocalScript("master", script); err != nil {
+						t.Fatalf("unable to create role: %s", err)
+					}
+				},
+				Config: testAccCheckMultiDatabaseUser(t, "nested", "login", map[string]interface{}{"username": "multi_db_user_nested", "login_name": "multi_db_login_nested", "login_password": "valueIsH8kd$¡", "databases": "[\"master\"]", "roles": "[\"multi_db_nested\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMultiDatabaseUserExists("mssql_multi_database_user.nested"),
+					resource.TestCheckResourceAttr("mssql_multi_database_user.nested", "database_states.master", "in_sync"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMultiDatabaseUser_Local_UnknownDatabase(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckMultiDatabaseUser(t, "unknown", "login", map[string]interface{}{"username": "multi_db_user_unknown", "login_name": "multi_db_login_unknown", "login_password": "valueIsH8kd$¡", "databases": "[\"master\", \"multi_db_does_not_exist\"]"}),
+				ExpectError: regexp.MustCompile("multi_db_does_not_exist"),
+			},
+		},
+	})
+}
+
+func testAccCheckMultiDatabaseUser(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_login" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				login_name = "{{ .login_name }}"
+				password   = "{{ .login_password }}"
+			}
+			resource "mssql_multi_database_user" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				databases {
+					{{ with .databases }}names = {{ . }}{{ end }}
+					{{ with .pattern }}name_pattern = "{{ . }}"{{ end }}
+				}
+				username   = "{{ .username }}"
+				login_name = mssql_login.{{ .name }}.login_name
+				{{ with .roles }}roles = {{ . }}{{ end }}
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckMultiDatabaseUserDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_multi_database_user" {
+			continue
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		for _, database := range getDatabaseStatesFromState(rs.Primary.Attributes) {
+			user, err := connector.GetUser(database, rs.Primary.Attributes["username"])
+			if user != nil {
+				return fmt.Errorf("user still exists in database [%s]", database)
+			}
+			if err != nil {
+				return fmt.Errorf("expected no error, got %s", err)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccCheckMultiDatabaseUserExists(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_multi_database_user" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_multi_database_user", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		for _, database := range getDatabaseStatesFromState(rs.Primary.Attributes) {
+			user, err := connector.GetUser(database, rs.Primary.Attributes["username"])
+			if err != nil {
+				return fmt.Errorf("expected no error, got %s", err)
+			}
+			if user == nil {
+				return fmt.Errorf("user does not exist in database [%s]", database)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckMultiDatabaseUserNotExists(database, username string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		connector, err := getTestConnector(map[string]string{
+			serverProp + ".0.host":             "localhost",
+			serverProp + ".0.port":             "1433",
+			serverProp + ".0.login.0.username": os.Getenv("MSSQL_USERNAME"),
+			serverProp + ".0.login.0.password": os.Getenv("MSSQL_PASSWORD"),
+		})
+		if err != nil {
+			return err
+		}
+		user, err := connector.GetUser(database, username)
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if user != nil {
+			return fmt.Errorf("user still exists in database [%s]", database)
+		}
+		return nil
+	}
+}
+
+// getDatabaseStatesFromState returns the databases recorded in database_states.
+func getDatabaseStatesFromState(attrs map[string]string) []string {
+	var databases []string
+	for key := range attrs {
+		if database, ok := strings.CutPrefix(key, databaseStatesProp+"."); ok && database != "%" {
+			databases = append(databases, database)
+		}
+	}
+	return databases
+}
@@ -0,0 +1,442 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+// securableTypes are the securable types supported by mssql_object_permissions.
+var securableTypes = []string{"SCHEMA", "OBJECT", "TYPE", "ASSEMBLY", "XML SCHEMA COLLECTION", "CERTIFICATE", "DATABASE SCOPED CREDENTIAL"}
+
+// isSchemaScopedSecurable reports whether securables of the type are contained in a schema.
+func isSchemaScopedSecurable(securableType string) bool {
+	return securableType == "OBJECT" || securableType == "TYPE" || securableType == "XML SCHEMA COLLECTION"
+}
+
+func resourceObjectPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceObjectPermissionsCreate,
+		ReadContext:   resourceObjectPermissionsRead,
+		UpdateContext: resourceObjectPermissionsUpdate,
+		DeleteContext: resourceObjectPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceObjectPermissionsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			databaseProp: {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  defaultDatabaseDefault,
+			},
+			principalNameProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			principalIdProp: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			securableTypeProp: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(securableTypes, false),
+			},
+			securableSchemaProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SQLIdentifier,
+			},
+			securableNameProp: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			columnsProp: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			permissionsProp: {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{permissionsProp, denyPermissionsProp},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.SQLIdentifierPermission,
+				},
+			},
+			denyPermissionsProp: {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{permissionsProp, denyPermissionsProp},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.SQLIdentifierPermission,
+				},
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				securableType := diff.Get(securableTypeProp).(string)
+				if diff.Get(securableSchemaProp).(string) != "" && !isSchemaScopedSecurable(securableType) {
+					return errors.Errorf("%s cannot be set for securable type %s", securableSchemaProp, securableType)
+				}
+				if diff.Get(columnsProp).(*schema.Set).Len() > 0 && securableType != "OBJECT" {
+					return errors.Errorf("%s can only be set for securable type OBJECT", columnsProp)
+				}
+				for _, permission := range toStringSlice(diff.Get(denyPermissionsProp).(*schema.Set).List()) {
+					if diff.Get(permissionsProp).(*schema.Set).Contains(permission) {
+						return errors.Errorf("permission %s cannot be in both %s and %s", permission, permissionsProp, denyPermissionsProp)
+					}
+				}
+				return nil
+			},
+			// Switching between permissions on the object and on some of its columns recreates the resource
+			customdiff.ForceNewIfChange(columnsProp, func(ctx context.Context, old, new, meta interface{}) bool {
+				return (old.(*schema.Set).Len() == 0) != (new.(*schema.Set).Len() == 0)
+			}),
+			customizeDiffPermissionCatalog(objectPermissionClass, permissionsProp, denyPermissionsProp),
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Update: defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type ObjectPermissionsConnector interface {
+	GetObjectPermissions(ctx context.Context, database, principalName string, securable model.Securable) (*model.ObjectPermissions, error)
+	UpdateObjectPermissions(ctx context.Context, database, principalName string, securable model.Securable, permissions []string, changeType string) error
+}
+
+func resourceObjectPermissionsCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "objectpermissions", "create")
+
+	// Schema-scoped securables are looked up in dbo unless a schema is given
+	if data.Get(securableSchemaProp).(string) == "" && isSchemaScopedSecurable(data.Get(securableTypeProp).(string)) {
+		if err := data.Set(securableSchemaProp, defaultDboPropDefault); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	logger.Debug().Msgf("Create %s", getObjectPermissionsID(data))
+
+	database := data.Get(databaseProp).(string)
+	principalName := data.Get(principalNameProp).(string)
+	securable := getSecurable(data)
+	permissions := toStringSlice(data.Get(permissionsProp).(*schema.Set).List())
+
+	connector, err := getObjectPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.UpdateObjectPermissions(ctx, database, principalName, securable, permissions, "GRANT"); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to grant permissions [%s] on %s to principal [%s] in database [%s]", strings.Join(permissions, ", "), securableString(securable), principalName, database))
+	}
+	denyPermissions := toStringSlice(data.Get(denyPermissionsProp).(*schema.Set).List())
+	if err = connector.UpdateObjectPermissions(ctx, database, principalName, securable, denyPermissions, "DENY"); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to deny permissions [%s] on %s to principal [%s] in database [%s]", strings.Join(denyPermissions, ", "), securableString(securable), principalName, database))
+	}
+
+	data.SetId(getObjectPermissionsID(data))
+
+	logger.Info().Msgf("created permissions on %s for principal [%s] in database [%s]", securableString(securable), principalName, database)
+
+	return resourceObjectPermissionsRead(ctx, data, meta)
+}
+
+func resourceObjectPermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "objectpermissions", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	principalName := data.Get(principalNameProp).(string)
+	securable := getSecurable(data)
+
+	connector, err := getObjectPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissions, err := connector.GetObjectPermissions(ctx, database, principalName, securable)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read permissions on %s for principal [%s] in database [%s]", securableString(securable), principalName, database))
+	}
+	if permissions == nil {
+		logger.Info().Msgf("No principal [%s] or securable %s found in database [%s]", principalName, securableString(securable), database)
+		data.SetId("")
+		return nil
+	}
+
+	// Dropped columns are removed from the state and other columns holding any of the managed permissions are added
+	// to it, so that the difference shows up in the plan and the permissions are revoked from them
+	columns := permissions.Securable.Columns
+	managed := append(toStringSlice(data.Get(permissionsProp).(*schema.Set).List()), toStringSlice(data.Get(denyPermissionsProp).(*schema.Set).List())...)
+	for column, other := range permissions.OtherColumnPermissions {
+		for _, permission := range other {
+			if containsString(managed, permission) {
+				columns = append(columns, column)
+				break
+			}
+		}
+	}
+
+	if err = data.Set(principalIdProp, permissions.PrincipalID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = data.Set(permissionsProp, permissions.Permissions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = data.Set(denyPermissionsProp, permissions.DenyPermissions); err != nil {
+		return diag.FromErr(err)
+	}
+	if len(securable.Columns) > 0 {
+		if err = data.Set(columnsProp, columns); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceObjectPermissionsUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "objectpermissions", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	principalName := data.Get(principalNameProp).(string)
+	securable := getSecurable(data)
+
+	oldGrant, newGrant := data.GetChange(permissionsProp)
+	toGrant, toRevokeGrant := stringSetDiff(oldGrant.(*schema.Set), newGrant.(*schema.Set))
+	oldDeny, newDeny := data.GetChange(denyPermissionsProp)
+	toDeny, toRevokeDeny := stringSetDiff(oldDeny.(*schema.Set), newDeny.(*schema.Set))
+	oldColumns, newColumns := data.GetChange(columnsProp)
+	addedColumns, removedColumns := stringSetDiff(oldColumns.(*schema.Set), newColumns.(*schema.Set))
+
+	// Changes of the permission lists apply to the columns that stay managed, or to the whole object. Added columns get
+	// every configured permission, removed columns lose every previously managed one.
+	kept := securable
+	if len(securable.Columns) > 0 {
+		kept.Columns = nil
+		for _, column := range securable.Columns {
+			if !containsString(addedColumns, column) {
+				kept.Columns = append(kept.Columns, column)
+			}
+		}
+	}
+
+	type objectPermissionChange struct {
+		securable model.Securable
+		permissionChange
+	}
+	var changes []objectPermissionChange
+	if len(removedColumns) > 0 {
+		removed := securable
+		removed.Columns = removedColumns
+		changes = append(changes, objectPermissionChange{removed, permissionChange{append(toStringSlice(oldGrant.(*schema.Set).List()), toStringSlice(oldDeny.(*schema.Set).List())...), "REVOKE"}})
+	}
+	if len(securable.Columns) == 0 || len(kept.Columns) > 0 {
+		changes = append(changes,
+			objectPermissionChange{kept, permissionChange{append(toRevokeGrant, toRevokeDeny...), "REVOKE"}},
+			objectPermissionChange{kept, permissionChange{toGrant, "GRANT"}},
+			objectPermissionChange{kept, permissionChange{toDeny, "DENY"}},
+		)
+	}
+	if len(addedColumns) > 0 {
+		added := securable
+		added.Columns = addedColumns
+		changes = append(changes,
+			objectPermissionChange{added, permissionChange{toStringSlice(newGrant.(*schema.Set).List()), "GRANT"}},
+			objectPermissionChange{added, permissionChange{toStringSlice(newDeny.(*schema.Set).List()), "DENY"}},
+		)
+	}
+
+	connector, err := getObjectPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, change := range changes {
+		if len(change.permissions) == 0 {
+			continue
+		}
+		if err = connector.UpdateObjectPermissions(ctx, database, principalName, change.securable, change.permissions, change.changeType); err != nil {
+			for prop, oldValue := range map[string]interface{}{permissionsProp: oldGrant, denyPermissionsProp: oldDeny, columnsProp: oldColumns} {
+				if setErr := data.Set(prop, oldValue.(*schema.Set).List()); setErr != nil {
+					logger.Error().Err(setErr).Msgf("Failed to revert %s state after update error", prop)
+				}
+			}
+			return diag.FromErr(errors.Wrapf(err, "unable to update permissions on %s for principal [%s] in database [%s]", securableString(securable), principalName, database))
+		}
+	}
+
+	logger.Info().Msgf("updated permissions on %s for principal [%s] in database [%s]", securableString(securable), principalName, database)
+
+	return resourceObjectPermissionsRead(ctx, data, meta)
+}
+
+func resourceObjectPermissionsDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "objectpermissions", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	database := data.Get(databaseProp).(string)
+	principalName := data.Get(principalNameProp).(string)
+	securable := getSecurable(data)
+	// REVOKE removes denied permissions as well
+	permissions := append(
+		toStringSlice(data.Get(permissionsProp).(*schema.Set).List()),
+		toStringSlice(data.Get(denyPermissionsProp).(*schema.Set).List())...,
+	)
+
+	connector, err := getObjectPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := connector.GetObjectPermissions(ctx, database, principalName, securable)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read permissions on %s for principal [%s] in database [%s]", securableString(securable), principalName, database))
+	}
+	// Nothing is left to revoke if the principal, the securable or all of the columns have been dropped
+	if current != nil && (len(securable.Columns) == 0 || len(current.Securable.Columns) > 0) {
+		if err = connector.UpdateObjectPermissions(ctx, database, principalName, current.Securable, permissions, "REVOKE"); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to revoke permissions on %s from principal [%s] in database [%s]", securableString(securable), principalName, database))
+		}
+	}
+
+	data.SetId("")
+
+	logger.Info().Msgf("revoked permissions on %s from principal [%s] in database [%s]", securableString(securable), principalName, database)
+
+	return nil
+}
+
+func resourceObjectPermissionsImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(meta, "objectpermissions", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	server, u, err := serverFromId(data.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err = data.Set(serverProp, server); err != nil {
+		return nil, err
+	}
+
+	// /<database>/object_permission/<principal>/<type>[/<schema>]/<name>
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 6 && len(parts) != 7 {
+		return nil, errors.New("invalid ID")
+	}
+	securableType := strings.ReplaceAll(parts[4], "_", " ")
+	if isSchemaScopedSecurable(securableType) != (len(parts) == 7) {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(databaseProp, parts[1]); err != nil {
+		return nil, err
+	}
+	if err = data.Set(principalNameProp, parts[3]); err != nil {
+		return nil, err
+	}
+	if err = data.Set(securableTypeProp, securableType); err != nil {
+		return nil, err
+	}
+	if len(parts) == 7 {
+		if err = data.Set(securableSchemaProp, parts[5]); err != nil {
+			return nil, err
+		}
+	}
+	if err = data.Set(securableNameProp, parts[len(parts)-1]); err != nil {
+		return nil, err
+	}
+
+	data.SetId(getObjectPermissionsID(data))
+
+	database := data.Get(databaseProp).(string)
+	principalName := data.Get(principalNameProp).(string)
+	securable := getSecurable(data)
+
+	connector, err := getObjectPermissionsConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := connector.GetObjectPermissions(ctx, database, principalName, securable)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read permissions on %s for principal [%s] in database [%s] for import", securableString(securable), principalName, database)
+	}
+	if permissions == nil {
+		return nil, errors.Errorf("no principal [%s] or securable %s found in database [%s] for import", principalName, securableString(securable), database)
+	}
+
+	if err = data.Set(principalIdProp, permissions.PrincipalID); err != nil {
+		return nil, err
+	}
+	if err = data.Set(permissionsProp, permissions.Permissions); err != nil {
+		return nil, err
+	}
+	if err = data.Set(denyPermissionsProp, permissions.DenyPermissions); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+func getSecurable(data *schema.ResourceData) model.Securable {
+	return model.Securable{
+		Type:    data.Get(securableTypeProp).(string),
+		Schema:  data.Get(securableSchemaProp).(string),
+		Name:    data.Get(securableNameProp).(string),
+		Columns: toStringSlice(data.Get(columnsProp).(*schema.Set).List()),
+	}
+}
+
+// securableString formats the securable for messages, e.g. OBJECT::[dbo].[usp_x] or OBJECT::[dbo].[t] ([a], [b]).
+func securableString(securable model.Securable) string {
+	result := securable.Type + "::[" + securable.Name + "]"
+	if securable.Schema != "" {
+		result = securable.Type + "::[" + securable.Schema + "].[" + securable.Name + "]"
+	}
+	if len(securable.Columns) > 0 {
+		result += " ([" + strings.Join(securable.Columns, "], [") + "])"
+	}
+	return result
+}
+
+func getObjectPermissionsConnector(meta interface{}, data *schema.ResourceData) (ObjectPermissionsConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(ObjectPermissionsConnector), nil
+}
@@ -0,0 +1,31 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccObjectPermissions_Local_BasicImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckObjectPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckObjectPermissions(t, "test_import", "login", map[string]interface{}{"username": "object_perm_import_user", "schema_name": "object_perm_import_schema", "securable_type": "SCHEMA", "securable_name": "object_perm_import_schema", "permissions": "[\"SELECT\", \"INSERT\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsExist("mssql_object_permissions.test_import"),
+				),
+			},
+			{
+				ResourceName:      "mssql_object_permissions.test_import",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateId("mssql_object_permissions.test_import", false),
+			},
+		},
+	})
+}
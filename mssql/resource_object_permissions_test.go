@@ -0,0 +1,408 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccObjectPermissions_Local_Schema(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckObjectPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckObjectPermissions(t, "schema", "login", map[string]interface{}{"username": "object_perm_user", "schema_name": "object_perm_schema", "securable_type": "SCHEMA", "securable_name": "object_perm_schema", "permissions": "[\"SELECT\", \"EXECUTE\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsExist("mssql_object_permissions.schema"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "database", "master"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "principal_name", "object_perm_user"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "securable_type", "SCHEMA"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "securable_schema", ""),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "securable_name", "object_perm_schema"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mssql_object_permissions.schema", "permissions.*", "SELECT"),
+					resource.TestCheckTypeSetElemAttr("mssql_object_permissions.schema", "permissions.*", "EXECUTE"),
+					resource.TestCheckResourceAttrSet("mssql_object_permissions.schema", "principal_id"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "server.#", "1"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "server.0.host", "localhost"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "server.0.login.#", "1"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "server.0.login.0.username", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "server.0.azure_login.#", "0"),
+				),
+			},
+			{
+				Config: testAccCheckObjectPermissions(t, "schema", "login", map[string]interface{}{"username": "object_perm_user", "schema_name": "object_perm_schema", "securable_type": "SCHEMA", "securable_name": "object_perm_schema", "permissions": "[\"SELECT\", \"VIEW DEFINITION\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsExist("mssql_object_permissions.schema"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.schema", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mssql_object_permissions.schema", "permissions.*", "SELECT"),
+					resource.TestCheckTypeSetElemAttr("mssql_object_permissions.schema", "permissions.*", "VIEW DEFINITION"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObjectPermissions_Local_Object(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccCheckObjectPermissionsDestroy(state); err != nil {
+				return err
+			}
+			return testAccExecuteLocalScript("master", "DROP PROCEDURE IF EXISTS [dbo].[object_perm_proc]")
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "CREATE OR ALTER PROCEDURE [dbo].[object_perm_proc] AS SELECT 1"); err != nil {
+						t.Fatalf("unable to create procedure: %s", err)
+					}
+				},
+				Config: testAccCheckObjectPermissions(t, "object", "login", map[string]interface{}{"username": "object_perm_proc_user", "securable_type": "OBJECT", "securable_name": "object_perm_proc", "permissions": "[\"EXECUTE\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsExist("mssql_object_permissions.object"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.object", "securable_type", "OBJECT"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.object", "securable_schema", "dbo"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.object", "securable_name", "object_perm_proc"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.object", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.object", "permissions.0", "EXECUTE"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObjectPermissions_Local_WithGrantOption(t *testing.T) {
+	securable := map[string]interface{}{"username": "object_perm_grant_user", "securable_type": "OBJECT", "securable_name": "object_perm_grant"}
+	withPermissions := func(permissions string) map[string]interface{} {
+		data := map[string]interface{}{"permissions": permissions}
+		for k, v := range securable {
+			data[k] = v
+		}
+		return data
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccCheckObjectPermissionsDestroy(state); err != nil {
+				return err
+			}
+			return testAccExecuteLocalScript("master", "DROP TABLE IF EXISTS [dbo].[object_perm_grant]")
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "DROP TABLE IF EXISTS [dbo].[object_perm_grant]; CREATE TABLE [dbo].[object_perm_grant] (id int)"); err != nil {
+						t.Fatalf("unable to create table: %s", err)
+					}
+				},
+				Config: testAccCheckObjectPermissions(t, "grant", "login", withPermissions("[\"SELECT\", \"INSERT\"]")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsExist("mssql_object_permissions.grant"),
+				),
+			},
+			{
+				// A permission granted WITH GRANT OPTION outside of Terraform is revoked with CASCADE
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "GRANT SELECT ON OBJECT::[dbo].[object_perm_grant] TO [object_perm_grant_user] WITH GRANT OPTION"); err != nil {
+						t.Fatalf("unable to grant permission: %s", err)
+					}
+				},
+				Config: testAccCheckObjectPermissions(t, "grant", "login", withPermissions("[\"INSERT\"]")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsExist("mssql_object_permissions.grant"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.grant", "permissions.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObjectPermissions_Local_Columns(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccCheckObjectPermissionsDestroy(state); err != nil {
+				return err
+			}
+			return testAccExecuteLocalScript("master", "DROP TABLE IF EXISTS [dbo].[object_perm_pii]")
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "DROP TABLE IF EXISTS [dbo].[object_perm_pii]; CREATE TABLE [dbo].[object_perm_pii] (id int, name nvarchar(50), ssn nvarchar(11))"); err != nil {
+						t.Fatalf("unable to create table: %s", err)
+					}
+				},
+				Config: testAccCheckObjectPermissions(t, "columns", "login", map[string]interface{}{"username": "object_perm_col_user", "securable_type": "OBJECT", "securable_name": "object_perm_pii", "columns": "[\"id\", \"name\"]", "permissions": "[\"SELECT\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsExist("mssql_object_permissions.columns"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.columns", "columns.#", "2"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.columns", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.columns", "permissions.0", "SELECT"),
+				),
+			},
+			{
+				// id is no longer managed, ssn is added and UPDATE is denied on the managed columns
+				Config: testAccCheckObjectPermissions(t, "columns", "login", map[string]interface{}{"username": "object_perm_col_user", "securable_type": "OBJECT", "securable_name": "object_perm_pii", "columns": "[\"name\", \"ssn\"]", "permissions": "[\"SELECT\"]", "deny_permissions": "[\"UPDATE\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsExist("mssql_object_permissions.columns"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.columns", "columns.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mssql_object_permissions.columns", "columns.*", "name"),
+					resource.TestCheckTypeSetElemAttr("mssql_object_permissions.columns", "columns.*", "ssn"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.columns", "deny_permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.columns", "deny_permissions.0", "UPDATE"),
+				),
+			},
+			{
+				// A managed permission granted outside of Terraform on a column added to the table is revoked
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "ALTER TABLE [dbo].[object_perm_pii] ADD email nvarchar(50); GRANT SELECT ([email]) ON [dbo].[object_perm_pii] TO [object_perm_col_user]"); err != nil {
+						t.Fatalf("unable to grant permission on added column: %s", err)
+					}
+				},
+				Config: testAccCheckObjectPermissions(t, "columns", "login", map[string]interface{}{"username": "object_perm_col_user", "securable_type": "OBJECT", "securable_name": "object_perm_pii", "columns": "[\"name\", \"ssn\"]", "permissions": "[\"SELECT\"]", "deny_permissions": "[\"UPDATE\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_object_permissions.columns", "columns.#", "2"),
+					testAccCheckObjectPermissionsOtherColumnRevoked("mssql_object_permissions.columns", "email"),
+				),
+			},
+			{
+				// A dropped column shows up as a difference
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "ALTER TABLE [dbo].[object_perm_pii] DROP COLUMN ssn"); err != nil {
+						t.Fatalf("unable to drop column: %s", err)
+					}
+				},
+				Config:             testAccCheckObjectPermissions(t, "columns", "login", map[string]interface{}{"username": "object_perm_col_user", "securable_type": "OBJECT", "securable_name": "object_perm_pii", "columns": "[\"name\", \"ssn\"]", "permissions": "[\"SELECT\"]", "deny_permissions": "[\"UPDATE\"]"}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccObjectPermissions_Local_Destroy(t *testing.T) {
+	securable := map[string]interface{}{"username": "object_perm_destroy_user", "securable_type": "OBJECT", "securable_name": "object_perm_destroy", "columns": "[\"ssn\"]", "permissions": "[\"INSERT\"]", "deny_permissions": "[\"SELECT\"]"}
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			return testAccExecuteLocalScript("master", "DROP TABLE IF EXISTS [dbo].[object_perm_destroy]")
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "DROP TABLE IF EXISTS [dbo].[object_perm_destroy]; CREATE TABLE [dbo].[object_perm_destroy] (id int, ssn nvarchar(11))"); err != nil {
+						t.Fatalf("unable to create table: %s", err)
+					}
+				},
+				Config: testAccCheckObjectPermissions(t, "destroy", "login", securable),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsExist("mssql_object_permissions.destroy"),
+					resource.TestCheckResourceAttr("mssql_object_permissions.destroy", "deny_permissions.#", "1"),
+				),
+			},
+			{
+				// The principal outlives the permissions, which must not leave the DENY behind
+				Config: testAccCheckObjectPermissions(t, "destroy", "login", map[string]interface{}{"username": "object_perm_destroy_user", "without_permissions": true}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectPermissionsRevoked("mssql_user.destroy", model.Securable{Type: "OBJECT", Schema: "dbo", Name: "object_perm_destroy", Columns: []string{"ssn"}}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckObjectPermissions(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_user" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .database }}database = "{{ . }}"{{ end }}
+				username      = "{{ .username }}"
+				without_login = true
+			}
+			{{ if .schema_name }}
+			resource "mssql_database_schema" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .database }}database = "{{ . }}"{{ end }}
+				schema_name = "{{ .schema_name }}"
+			}
+			{{ end }}
+			{{ if not .without_permissions }}
+			resource "mssql_object_permissions" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				{{ with .database }}database = "{{ . }}"{{ end }}
+				principal_name   = mssql_user.{{ .name }}.username
+				securable_type   = "{{ .securable_type }}"
+				{{ with .securable_schema }}securable_schema = "{{ . }}"{{ end }}
+				securable_name   = "{{ .securable_name }}"
+				{{ with .columns }}columns = {{ . }}{{ end }}
+				{{ with .permissions }}permissions = {{ . }}{{ end }}
+				{{ with .deny_permissions }}deny_permissions = {{ . }}{{ end }}
+				{{ if .schema_name }}
+				depends_on = [mssql_database_schema.{{ .name }}]
+				{{ end }}
+			}
+			{{ end }}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckObjectPermissionsDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_object_permissions" {
+			continue
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		permissions, err := connector.GetObjectPermissions(rs.Primary.Attributes["database"], rs.Primary.Attributes["principal_name"], getSecurableFromState(rs.Primary.Attributes))
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if permissions != nil && len(permissions.Permissions)+len(permissions.DenyPermissions) > 0 {
+			return fmt.Errorf("permissions still exist")
+		}
+	}
+	return nil
+}
+
+// testAccCheckObjectPermissionsRevoked checks that the user of the resource has no permission left on the securable.
+func testAccCheckObjectPermissionsRevoked(resource string, securable model.Securable) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		permissions, err := connector.GetObjectPermissions(rs.Primary.Attributes["database"], rs.Primary.Attributes["username"], securable)
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if permissions == nil {
+			return fmt.Errorf("expected principal [%s] to exist", rs.Primary.Attributes["username"])
+		}
+		if len(permissions.Permissions)+len(permissions.DenyPermissions) > 0 {
+			return fmt.Errorf("permissions still exist: %v, denied: %v", permissions.Permissions, permissions.DenyPermissions)
+		}
+		return nil
+	}
+}
+
+func testAccCheckObjectPermissionsExist(resource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		if rs.Type != "mssql_object_permissions" {
+			return fmt.Errorf("expected resource of type %s, got %s", "mssql_object_permissions", rs.Type)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no record ID is set")
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		permissions, err := connector.GetObjectPermissions(rs.Primary.Attributes["database"], rs.Primary.Attributes["principal_name"], getSecurableFromState(rs.Primary.Attributes))
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if permissions == nil {
+			return fmt.Errorf("principal or securable does not exist")
+		}
+		if expected, actual := rs.Primary.Attributes["permissions.#"], fmt.Sprint(len(permissions.Permissions)); expected != actual {
+			return fmt.Errorf("expected %s permissions, got %s", expected, actual)
+		}
+		if expected, actual := rs.Primary.Attributes["deny_permissions.#"], fmt.Sprint(len(permissions.DenyPermissions)); expected != actual {
+			return fmt.Errorf("expected %s denied permissions, got %s", expected, actual)
+		}
+		return nil
+	}
+}
+
+// testAccCheckObjectPermissionsOtherColumnRevoked checks that the principal holds no permission on a column of the
+// object that is not listed in columns.
+func testAccCheckObjectPermissionsOtherColumnRevoked(resource, column string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		permissions, err := connector.GetObjectPermissions(rs.Primary.Attributes["database"], rs.Primary.Attributes["principal_name"], getSecurableFromState(rs.Primary.Attributes))
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if permissions == nil {
+			return fmt.Errorf("principal or securable does not exist")
+		}
+		if other := permissions.OtherColumnPermissions[column]; len(other) > 0 {
+			return fmt.Errorf("expected no permissions on column [%s], got %v", column, other)
+		}
+		return nil
+	}
+}
+
+func getSecurableFromState(attrs map[string]string) model.Securable {
+	securable := model.Securable{
+		Type:   attrs[securableTypeProp],
+		Schema: attrs[securableSchemaProp],
+		Name:   attrs[securableNameProp],
+	}
+	for key, value := range attrs {
+		if strings.HasPrefix(key, columnsProp+".") && key != columnsProp+".#" {
+			securable.Columns = append(securable.Columns, value)
+		}
+	}
+	return securable
+}
@@ -0,0 +1,336 @@
+package mssql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+// connectSQLPermission is granted to every login on creation. It is never revoked unless it is managed explicitly.
+const connectSQLPermission = "CONNECT SQL"
+
+func resourceServerPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServerPermissionsCreate,
+		ReadContext:   resourceServerPermissionsRead,
+		UpdateContext: resourceServerPermissionsUpdate,
+		DeleteContext: resourceServerPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServerPermissionsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			serverProp: {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: getServerSchema(serverProp),
+				},
+			},
+			principalNameProp: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			principalIdProp: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			permissionsProp: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.SQLServerPermission,
+				},
+			},
+			denyPermissionsProp: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.SQLServerPermission,
+				},
+			},
+			modeProp: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      modeAdditive,
+				ValidateFunc: validation.StringInSlice([]string{modeAdditive, modeAuthoritative}, false),
+			},
+		},
+		CustomizeDiff: customizeDiffPermissionCatalog(serverPermissionClass, permissionsProp, denyPermissionsProp),
+		Timeouts: &schema.ResourceTimeout{
+			Create: defaultTimeout,
+			Read:   defaultTimeout,
+			Update: defaultTimeout,
+			Delete: defaultTimeout,
+		},
+	}
+}
+
+type ServerPermissionsConnector interface {
+	GetServerPermissions(ctx context.Context, principalName string) (*model.ServerPermissions, error)
+	UpdateServerPermissions(ctx context.Context, principalName string, permissions []string, changeType string) error
+}
+
+func resourceServerPermissionsCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "serverpermissions", "create")
+	logger.Debug().Msgf("Create %s", getServerPermissionsID(data))
+
+	principalName := data.Get(principalNameProp).(string)
+	permissions := toStringSlice(data.Get(permissionsProp).(*schema.Set).List())
+	denyPermissions := toStringSlice(data.Get(denyPermissionsProp).(*schema.Set).List())
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := connector.GetServerPermissions(ctx, principalName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read server permissions for principal [%s]", principalName))
+	}
+	if current == nil {
+		return diag.Errorf("principal [%s] does not exist", principalName)
+	}
+
+	if data.Get(modeProp).(string) == modeAuthoritative {
+		if err = revokeUnmanagedPermissions(ctx, data, connector, current); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err = connector.UpdateServerPermissions(ctx, principalName, permissions, "GRANT"); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to grant server permissions [%s] to principal [%s]", strings.Join(permissions, ", "), principalName))
+	}
+	if err = connector.UpdateServerPermissions(ctx, principalName, denyPermissions, "DENY"); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to deny server permissions [%s] to principal [%s]", strings.Join(denyPermissions, ", "), principalName))
+	}
+
+	data.SetId(getServerPermissionsID(data))
+
+	logger.Info().Msgf("created server permissions for principal [%s]", principalName)
+
+	return resourceServerPermissionsRead(ctx, data, meta)
+}
+
+func resourceServerPermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "serverpermissions", "read")
+	logger.Debug().Msgf("Read %s", data.Id())
+
+	principalName := data.Get(principalNameProp).(string)
+	authoritative := data.Get(modeProp).(string) == modeAuthoritative
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissions, err := connector.GetServerPermissions(ctx, principalName)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to read server permissions for principal [%s]", principalName))
+	}
+	if permissions == nil {
+		logger.Info().Msgf("No principal [%s] found", principalName)
+		data.SetId("")
+		return nil
+	}
+
+	if err = data.Set(principalIdProp, permissions.PrincipalID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = data.Set(permissionsProp, managedPermissions(permissions.Permissions, data.Get(permissionsProp).(*schema.Set), authoritative, connectSQLPermission)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = data.Set(denyPermissionsProp, managedPermissions(permissions.DenyPermissions, data.Get(denyPermissionsProp).(*schema.Set), authoritative, "")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceServerPermissionsUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "serverpermissions", "update")
+	logger.Debug().Msgf("Update %s", data.Id())
+
+	principalName := data.Get(principalNameProp).(string)
+
+	oldGrant, newGrant := data.GetChange(permissionsProp)
+	toGrant, toRevokeGrant := stringSetDiff(oldGrant.(*schema.Set), newGrant.(*schema.Set))
+	oldDeny, newDeny := data.GetChange(denyPermissionsProp)
+	toDeny, toRevokeDeny := stringSetDiff(oldDeny.(*schema.Set), newDeny.(*schema.Set))
+
+	// Store old values for all properties that might change
+	oldValues := make(map[string]interface{})
+	for _, prop := range []string{permissionsProp, denyPermissionsProp, modeProp} {
+		if data.HasChange(prop) {
+			oldValue, _ := data.GetChange(prop)
+			if oldSet, ok := oldValue.(*schema.Set); ok {
+				oldValues[prop] = oldSet.List()
+			} else {
+				oldValues[prop] = oldValue
+			}
+		}
+	}
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if data.HasChange(modeProp) && data.Get(modeProp).(string) == modeAuthoritative {
+		current, err := connector.GetServerPermissions(ctx, principalName)
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to read server permissions for principal [%s]", principalName))
+		}
+		if current != nil {
+			if err = revokeUnmanagedPermissions(ctx, data, connector, current); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	for _, change := range []struct {
+		permissions []string
+		changeType  string
+	}{
+		{append(toRevokeGrant, toRevokeDeny...), "REVOKE"},
+		{toGrant, "GRANT"},
+		{toDeny, "DENY"},
+	} {
+		if len(change.permissions) == 0 {
+			continue
+		}
+		if err = connector.UpdateServerPermissions(ctx, principalName, change.permissions, change.changeType); err != nil {
+			for prop, oldValue := range oldValues {
+				if setErr := data.Set(prop, oldValue); setErr != nil {
+					logger.Error().Err(setErr).Msgf("Failed to revert %s state after update error", prop)
+				}
+			}
+			return diag.FromErr(errors.Wrapf(err, "unable to update server permissions for principal [%s]", principalName))
+		}
+	}
+
+	logger.Info().Msgf("updated server permissions for principal [%s]", principalName)
+
+	return resourceServerPermissionsRead(ctx, data, meta)
+}
+
+func resourceServerPermissionsDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	logger := loggerFromMeta(meta, "serverpermissions", "delete")
+	logger.Debug().Msgf("Delete %s", data.Id())
+
+	principalName := data.Get(principalNameProp).(string)
+	permissions := append(
+		toStringSlice(data.Get(permissionsProp).(*schema.Set).List()),
+		toStringSlice(data.Get(denyPermissionsProp).(*schema.Set).List())...,
+	)
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = connector.UpdateServerPermissions(ctx, principalName, permissions, "REVOKE"); err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to revoke server permissions from principal [%s]", principalName))
+	}
+
+	data.SetId("")
+
+	logger.Info().Msgf("deleted server permissions for principal [%s]", principalName)
+
+	return nil
+}
+
+func resourceServerPermissionsImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(meta, "serverpermissions", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	server, u, err := serverFromId(data.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err = data.Set(serverProp, server); err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 3 {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(principalNameProp, parts[2]); err != nil {
+		return nil, err
+	}
+	if err = data.Set(modeProp, modeAdditive); err != nil {
+		return nil, err
+	}
+
+	data.SetId(getServerPermissionsID(data))
+
+	principalName := data.Get(principalNameProp).(string)
+
+	connector, err := getServerPermissionsConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := connector.GetServerPermissions(ctx, principalName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read server permissions for principal [%s] for import", principalName)
+	}
+	if permissions == nil {
+		return nil, errors.Errorf("no principal [%s] found for import", principalName)
+	}
+
+	// Import every permission except the implicit CONNECT SQL.
+	if err = data.Set(principalIdProp, permissions.PrincipalID); err != nil {
+		return nil, err
+	}
+	if err = data.Set(permissionsProp, managedPermissions(permissions.Permissions, nil, true, connectSQLPermission)); err != nil {
+		return nil, err
+	}
+	if err = data.Set(denyPermissionsProp, permissions.DenyPermissions); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+// revokeUnmanagedServerPermissions revokes the granted and denied permissions of the principal that are not configured.
+func revokeUnmanagedPermissions(ctx context.Context, data *schema.ResourceData, connector ServerPermissionsConnector, current *model.ServerPermissions) error {
+	var toRevoke []string
+	for _, p := range current.Permissions {
+		if !data.Get(permissionsProp).(*schema.Set).Contains(p) && p != connectSQLPermission {
+			toRevoke = append(toRevoke, p)
+		}
+	}
+	for _, p := range current.DenyPermissions {
+		if !data.Get(denyPermissionsProp).(*schema.Set).Contains(p) {
+			toRevoke = append(toRevoke, p)
+		}
+	}
+	if len(toRevoke) == 0 {
+		return nil
+	}
+	if err := connector.UpdateServerPermissions(ctx, current.PrincipalName, toRevoke, "REVOKE"); err != nil {
+		return errors.Wrapf(err, "unable to revoke unmanaged server permissions from principal [%s]", current.PrincipalName)
+	}
+	return nil
+}
+
+func getServerPermissionsConnector(meta interface{}, data *schema.ResourceData) (ServerPermissionsConnector, error) {
+	provider := meta.(model.Provider)
+	connector, err := provider.GetConnector(serverProp, data)
+	if err != nil {
+		return nil, err
+	}
+	return connector.(ServerPermissionsConnector), nil
+}
@@ -0,0 +1,31 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccServerPermissions_Local_BasicImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerPermissions(t, "test_import", "login", map[string]interface{}{"permissions": `["VIEW SERVER STATE"]`, "deny_permissions": `["ALTER ANY LOGIN"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerPermissionsExists("mssql_server_permissions.test_import", "VIEW SERVER STATE", "ALTER ANY LOGIN"),
+				),
+			},
+			{
+				ResourceName:      "mssql_server_permissions.test_import",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateId("mssql_server_permissions.test_import", false),
+			},
+		},
+	})
+}
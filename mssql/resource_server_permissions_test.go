@@ -0,0 +1,249 @@
+package mssql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccServerPermissions_Local_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerPermissions(t, "local_basic", "login", map[string]interface{}{"permissions": `["VIEW SERVER STATE"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerPermissionsExists("mssql_server_permissions.local_basic", "VIEW SERVER STATE"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "id", "sqlserver://localhost:1433/server_permissions/server_permissions_local_basic"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "principal_name", "server_permissions_local_basic"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "permissions.0", "VIEW SERVER STATE"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "deny_permissions.#", "0"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "mode", "additive"),
+					resource.TestCheckResourceAttrSet("mssql_server_permissions.local_basic", "principal_id"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "server.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "server.0.host", "localhost"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "server.0.port", "1433"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "server.0.login.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "server.0.login.0.username", os.Getenv("MSSQL_USERNAME")),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "server.0.login.0.password", os.Getenv("MSSQL_PASSWORD")),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "server.0.azure_login.#", "0"),
+				),
+			},
+			{
+				Config: testAccCheckServerPermissions(t, "local_basic", "login", map[string]interface{}{"permissions": `["VIEW ANY DATABASE", "IMPERSONATE ON LOGIN::server_permissions_local_basic_target"]`, "deny_permissions": `["ALTER ANY LOGIN"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerPermissionsExists("mssql_server_permissions.local_basic", "VIEW ANY DATABASE", "IMPERSONATE ON LOGIN::server_permissions_local_basic_target"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mssql_server_permissions.local_basic", "permissions.*", "IMPERSONATE ON LOGIN::server_permissions_local_basic_target"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "deny_permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_basic", "deny_permissions.0", "ALTER ANY LOGIN"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerPermissions_Local_Authoritative(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerPermissions(t, "local_authoritative", "login", map[string]interface{}{"permissions": `["VIEW SERVER STATE"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerPermissionsExists("mssql_server_permissions.local_authoritative", "VIEW SERVER STATE"),
+					testAccServerPermissionsExecute("mssql_server_permissions.local_authoritative", "GRANT VIEW ANY DEFINITION TO [server_permissions_local_authoritative]"),
+				),
+			},
+			{
+				Config: testAccCheckServerPermissions(t, "local_authoritative", "login", map[string]interface{}{"permissions": `["VIEW SERVER STATE"]`, "mode": "authoritative"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_authoritative", "mode", "authoritative"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_authoritative", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_authoritative", "permissions.0", "VIEW SERVER STATE"),
+					testAccCheckServerPermissionsNotExists("mssql_server_permissions.local_authoritative", "VIEW ANY DEFINITION"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerPermissions_Local_WithGrantOption(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerPermissionsDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerPermissions(t, "local_grant", "login", map[string]interface{}{"permissions": `["VIEW SERVER STATE", "VIEW ANY DATABASE"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerPermissionsExists("mssql_server_permissions.local_grant", "VIEW SERVER STATE"),
+					testAccServerPermissionsExecute("mssql_server_permissions.local_grant", "GRANT VIEW SERVER STATE TO [server_permissions_local_grant] WITH GRANT OPTION"),
+				),
+			},
+			{
+				// A permission granted WITH GRANT OPTION outside of Terraform is revoked with CASCADE
+				Config: testAccCheckServerPermissions(t, "local_grant", "login", map[string]interface{}{"permissions": `["VIEW ANY DATABASE"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_server_permissions.local_grant", "permissions.#", "1"),
+					testAccCheckServerPermissionsNotExists("mssql_server_permissions.local_grant", "VIEW SERVER STATE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServerPermissions(t *testing.T, name string, login string, data map[string]interface{}) string {
+	text := `resource "mssql_login" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				login_name = "server_permissions_{{ .name }}"
+				password   = "valueIsH8kd$A"
+			}
+			resource "mssql_login" "{{ .name }}_target" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				login_name = "server_permissions_{{ .name }}_target"
+				password   = "valueIsH8kd$A"
+			}
+			resource "mssql_server_permissions" "{{ .name }}" {
+				server {
+					host = "{{ .host }}"
+					{{if eq .login "fedauth"}}azuread_default_chain_auth {}{{ else if eq .login "msi"}}azuread_managed_identity_auth {}{{ else if eq .login "azure" }}azure_login {}{{ else }}login {}{{ end }}
+				}
+				principal_name = mssql_login.{{ .name }}.login_name
+				{{ with .permissions }}permissions = {{ . }}{{ end }}
+				{{ with .deny_permissions }}deny_permissions = {{ . }}{{ end }}
+				{{ with .mode }}mode = "{{ . }}"{{ end }}
+				depends_on = [mssql_login.{{ .name }}_target]
+			}`
+
+	data["name"] = name
+	data["login"] = login
+	switch login {
+	case "fedauth", "msi", "azure":
+		data["host"] = os.Getenv("TF_ACC_SQL_SERVER")
+	case "login":
+		data["host"] = "localhost"
+	default:
+		t.Fatalf("login expected to be one of 'login', 'azure', 'msi', 'fedauth', got %s", login)
+	}
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
+func testAccCheckServerPermissionsDestroy(state *terraform.State) error {
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "mssql_server_permissions" {
+			continue
+		}
+
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+
+		principalName := rs.Primary.Attributes[principalNameProp]
+		permissions, err := connector.GetServerPermissions(principalName)
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if permissions != nil && (len(permissions.Permissions) > 1 || len(permissions.DenyPermissions) > 0) {
+			return fmt.Errorf("server permissions still exist")
+		}
+	}
+	return nil
+}
+
+func testAccCheckServerPermissionsExists(resource string, expected ...string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		permissions, err := testAccGetServerPermissions(state, resource)
+		if err != nil {
+			return err
+		}
+		for _, e := range expected {
+			found := false
+			for _, p := range append(permissions.Permissions, permissions.DenyPermissions...) {
+				if p == e {
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("expected server permission %s, got %v", e, permissions.Permissions)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckServerPermissionsNotExists(resource string, unexpected string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		permissions, err := testAccGetServerPermissions(state, resource)
+		if err != nil {
+			return err
+		}
+		for _, p := range permissions.Permissions {
+			if p == unexpected {
+				return fmt.Errorf("server permission %s was not revoked", unexpected)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccServerPermissionsExecute(resource string, script string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		return connector.DataBaseExecuteScript("master", script)
+	}
+}
+
+func testAccGetServerPermissions(state *terraform.State, resource string) (*model.ServerPermissions, error) {
+	rs, ok := state.RootModule().Resources[resource]
+	if !ok {
+		return nil, fmt.Errorf("not found: %s", resource)
+	}
+	if rs.Type != "mssql_server_permissions" {
+		return nil, fmt.Errorf("expected resource of type %s, got %s", "mssql_server_permissions", rs.Type)
+	}
+	if rs.Primary.ID == "" {
+		return nil, fmt.Errorf("no record ID is set")
+	}
+	connector, err := getTestConnector(rs.Primary.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := connector.GetServerPermissions(rs.Primary.Attributes[principalNameProp])
+	if err != nil {
+		return nil, fmt.Errorf("expected no error, got %s", err)
+	}
+	if permissions == nil {
+		return nil, fmt.Errorf("principal does not exist")
+	}
+	return permissions, nil
+}
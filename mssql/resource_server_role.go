@@ -50,7 +50,16 @@ func resourceServerRole() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			permissionsProp: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.SQLServerPermission,
+				},
+			},
 		},
+		CustomizeDiff: customizeDiffPermissionCatalog(serverPermissionClass, permissionsProp),
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
 			Read:   defaultTimeout,
@@ -66,6 +75,8 @@ type ServerRoleConnector interface {
 	UpdateServerRoleName(ctx context.Context, newroleName string, oldroleName string) error
 	UpdateServerRoleOwner(ctx context.Context, roleName string, ownerName string) error
 	DeleteServerRole(ctx context.Context, roleName string) error
+	GetServerPermissions(ctx context.Context, principalName string) (*model.ServerPermissions, error)
+	UpdateServerPermissions(ctx context.Context, principalName string, permissions []string, changeType string) error
 }
 
 func resourceServerRoleCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -86,6 +97,12 @@ func resourceServerRoleCreate(ctx context.Context, data *schema.ResourceData, me
 
 	data.SetId(getServerRoleID(data))
 
+	if permissions := toStringSlice(data.Get(permissionsProp).(*schema.Set).List()); len(permissions) > 0 {
+		if err = connector.UpdateServerPermissions(ctx, roleName, permissions, "GRANT"); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to grant permissions to role [%s]", roleName))
+		}
+	}
+
 	logger.Info().Msgf("created role [%s]", roleName)
 
 	return resourceServerRoleRead(ctx, data, meta)
@@ -123,6 +140,20 @@ func resourceServerRoleRead(ctx context.Context, data *schema.ResourceData, meta
 		if err = data.Set(ownerIdProp, role.OwnerId); err != nil {
 			return diag.FromErr(err)
 		}
+
+		// Permissions are only managed when the state holds some, which is also the case after a failed update;
+		// all of them are then reported so that grants made outside of the role show up as drift.
+		if managed := data.Get(permissionsProp).(*schema.Set); managed.Len() > 0 {
+			current, err := connector.GetServerPermissions(ctx, roleName)
+			if err != nil {
+				return diag.FromErr(errors.Wrapf(err, "unable to get permissions of role [%s]", roleName))
+			}
+			if current != nil {
+				if err = data.Set(permissionsProp, managedPermissions(current.Permissions, managed, true, "")); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+		}
 	}
 
 	logger.Info().Msgf("read role [%s]", roleName)
@@ -191,6 +222,26 @@ func resourceServerRoleUpdate(ctx context.Context, data *schema.ResourceData, me
 			return diag.FromErr(errors.Wrapf(err, "unable to update role owner [%s]", roleName))
 		}
 	}
+	if data.HasChange(permissionsProp) {
+		oldPermissions, newPermissions := data.GetChange(permissionsProp)
+		toGrant, toRevoke := stringSetDiff(oldPermissions.(*schema.Set), newPermissions.(*schema.Set))
+		if len(toRevoke) > 0 {
+			if err = connector.UpdateServerPermissions(ctx, roleName, toRevoke, "REVOKE"); err != nil {
+				err = errors.Wrapf(err, "unable to revoke permissions from role [%s]", roleName)
+			}
+		}
+		if err == nil && len(toGrant) > 0 {
+			if err = connector.UpdateServerPermissions(ctx, roleName, toGrant, "GRANT"); err != nil {
+				err = errors.Wrapf(err, "unable to grant permissions to role [%s]", roleName)
+			}
+		}
+		if err != nil {
+			if setErr := data.Set(permissionsProp, oldPermissions); setErr != nil {
+				logger.Error().Err(setErr).Msg("Failed to revert permissions state after update error")
+			}
+			return diag.FromErr(err)
+		}
+	}
 
 	data.SetId(getServerRoleID(data))
 
@@ -2,6 +2,7 @@ package mssql
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
@@ -16,6 +17,9 @@ func resourceServerRoleMember() *schema.Resource {
 		ReadContext:   resourceServerRoleMemberRead,
 		UpdateContext: resourceServerRoleMemberUpdate,
 		DeleteContext: resourceServerRoleMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServerRoleMemberImport,
+		},
 		Schema: map[string]*schema.Schema{
 			serverProp: {
 				Type:     schema.TypeList,
@@ -37,6 +41,11 @@ func resourceServerRoleMember() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			authoritativeProp: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
@@ -52,6 +61,7 @@ type ServerRoleMemberConnector interface {
 	GetServerRoleMember(ctx context.Context, roleName string, managedMembers []string) (*model.ServerRoleMember, error)
 	UpdateServerRoleMember(ctx context.Context, roleName string, members []string, changeType string) error
 	DeleteServerRoleMember(ctx context.Context, roleName string, members []string) error
+	GetProtectedLogins(ctx context.Context) ([]string, error)
 }
 
 func resourceServerRoleMemberCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -74,6 +84,12 @@ func resourceServerRoleMemberCreate(ctx context.Context, data *schema.ResourceDa
 
 	logger.Info().Msgf("added members [%s] to role [%s]", strings.Join(toStringSlice(members), ", "), roleName)
 
+	if data.Get(authoritativeProp).(bool) {
+		if err = removeUnmanagedServerRoleMembers(ctx, connector, roleName, data.Get(membersProp).(*schema.Set)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceServerRoleMemberRead(ctx, data, meta)
 }
 
@@ -82,14 +98,15 @@ func resourceServerRoleMemberRead(ctx context.Context, data *schema.ResourceData
 	logger.Debug().Msgf("Read %s", data.Id())
 
 	roleName := data.Get(roleNameProp).(string)
-	managedMembers := toStringSlice(data.Get(membersProp).(*schema.Set).List())
+	managedMembers := data.Get(membersProp).(*schema.Set)
+	authoritative := data.Get(authoritativeProp).(bool)
 
 	connector, err := getServerRoleMemberConnector(meta, data)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	roleMembers, err := connector.GetServerRoleMember(ctx, roleName, managedMembers)
+	roleMembers, err := connector.GetServerRoleMember(ctx, roleName, nil)
 	if err != nil {
 		return diag.FromErr(errors.Wrapf(err, "unable to get role members for role [%s]", roleName))
 	}
@@ -97,10 +114,42 @@ func resourceServerRoleMemberRead(ctx context.Context, data *schema.ResourceData
 	if roleMembers == nil {
 		logger.Info().Msgf("role members for role [%s] do not exist", roleName)
 		data.SetId("")
-	} else {
-		if err = data.Set(membersProp, roleMembers.Members); err != nil {
-			return diag.FromErr(err)
+		return nil
+	}
+
+	protected, err := protectedServerRoleMembers(ctx, connector)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// In authoritative mode every member is reported so that unlisted members show up as drift, in additive mode
+	// only the listed members are. The connecting login, the groups and roles it connects through and sa are left
+	// alone unless listed.
+	members := make([]string, 0, len(roleMembers.Members))
+	var unmanaged []string
+	for _, member := range roleMembers.Members {
+		if configured, ok := managedServerRoleMember(managedMembers, member); ok {
+			members = append(members, configured)
+			continue
 		}
+		switch {
+		case isProtectedServerRoleMember(protected, member):
+		case authoritative:
+			members = append(members, member)
+		default:
+			unmanaged = append(unmanaged, member)
+		}
+	}
+	if err = data.Set(membersProp, members); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(unmanaged) > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("role [%s] has members not managed by Terraform", roleName),
+			Detail:   fmt.Sprintf("[%s] are members of role [%s] but not listed in %s. Set %s = true to remove them.", strings.Join(unmanaged, "], ["), roleName, membersProp, authoritativeProp),
+		}}
 	}
 
 	return nil
@@ -156,6 +205,13 @@ func resourceServerRoleMemberUpdate(ctx context.Context, data *schema.ResourceDa
 		logger.Info().Msgf("removed members from role [%s]", roleName)
 	}
 
+	// Members added outside of Terraform are part of the state once authoritative, but not when switching to it.
+	if data.Get(authoritativeProp).(bool) {
+		if err = removeUnmanagedServerRoleMembers(ctx, connector, roleName, newMembers); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	data.SetId(getServerRoleMemberID(data))
 
 	logger.Info().Msgf("updated role members for role [%s]", roleName)
@@ -186,6 +242,123 @@ func resourceServerRoleMemberDelete(ctx context.Context, data *schema.ResourceDa
 	return nil
 }
 
+func resourceServerRoleMemberImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	logger := loggerFromMeta(meta, "role_member", "import")
+	logger.Debug().Msgf("Import %s", data.Id())
+
+	server, u, err := serverFromId(data.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err = data.Set(serverProp, server); err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(u.Path, "/")
+	if len(parts) != 3 {
+		return nil, errors.New("invalid ID")
+	}
+	if err = data.Set(roleNameProp, parts[2]); err != nil {
+		return nil, err
+	}
+
+	data.SetId(getServerRoleMemberID(data))
+
+	roleName := data.Get(roleNameProp).(string)
+
+	connector, err := getServerRoleMemberConnector(meta, data)
+	if err != nil {
+		return nil, err
+	}
+
+	roleMembers, err := connector.GetServerRoleMember(ctx, roleName, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read role members for role [%s] for import", roleName)
+	}
+	if roleMembers == nil {
+		return nil, errors.Errorf("no role [%s] found for import", roleName)
+	}
+
+	protected, err := protectedServerRoleMembers(ctx, connector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Import every current member of the role, except the connecting login and sa.
+	members := make([]string, 0, len(roleMembers.Members))
+	for _, member := range roleMembers.Members {
+		if !isProtectedServerRoleMember(protected, member) {
+			members = append(members, member)
+		}
+	}
+	if err = data.Set(membersProp, members); err != nil {
+		return nil, err
+	}
+	if err = data.Set(authoritativeProp, false); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{data}, nil
+}
+
+// protectedServerRoleMembers returns the members that are never removed unless listed: the connecting login, the
+// principals in its login token and sa, whatever its current name.
+func protectedServerRoleMembers(ctx context.Context, connector ServerRoleMemberConnector) ([]string, error) {
+	logins, err := connector.GetProtectedLogins(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the current, login token and sa logins")
+	}
+	return logins, nil
+}
+
+func isProtectedServerRoleMember(protected []string, member string) bool {
+	for _, p := range protected {
+		if strings.EqualFold(p, member) {
+			return true
+		}
+	}
+	return false
+}
+
+// managedServerRoleMember returns the listed member matching member case-insensitively, as login names are compared
+// with the server collation, so that a member listed with another case does not show up as drift.
+func managedServerRoleMember(managed *schema.Set, member string) (string, bool) {
+	for _, m := range managed.List() {
+		if strings.EqualFold(m.(string), member) {
+			return m.(string), true
+		}
+	}
+	return "", false
+}
+
+// removeUnmanagedServerRoleMembers removes every member of the role that is neither in managed nor protected.
+func removeUnmanagedServerRoleMembers(ctx context.Context, connector ServerRoleMemberConnector, roleName string, managed *schema.Set) error {
+	roleMembers, err := connector.GetServerRoleMember(ctx, roleName, nil)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get role members for role [%s]", roleName)
+	}
+	if roleMembers == nil {
+		return nil
+	}
+	protected, err := protectedServerRoleMembers(ctx, connector)
+	if err != nil {
+		return err
+	}
+	var toRemove []string
+	for _, member := range roleMembers.Members {
+		if _, ok := managedServerRoleMember(managed, member); !ok && !isProtectedServerRoleMember(protected, member) {
+			toRemove = append(toRemove, member)
+		}
+	}
+	if len(toRemove) == 0 {
+		return nil
+	}
+	if err = connector.UpdateServerRoleMember(ctx, roleName, toRemove, "DROP"); err != nil {
+		return errors.Wrapf(err, "unable to remove unmanaged members [%s] from role [%s]", strings.Join(toRemove, ", "), roleName)
+	}
+	return nil
+}
+
 func getServerRoleMemberConnector(meta interface{}, data *schema.ResourceData) (ServerRoleMemberConnector, error) {
 	provider := meta.(model.Provider)
 	connector, err := provider.GetConnector(serverProp, data)
@@ -0,0 +1,31 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccServerRoleMember_Local_BasicImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRoleMember(t, "test_import", "login", map[string]interface{}{"role_name": "diskadmin", "members": "[\"login_test_0\", \"login_test_2\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleMemberExists("mssql_server_role_member.test_import"),
+				),
+			},
+			{
+				ResourceName:      "mssql_server_role_member.test_import",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateId("mssql_server_role_member.test_import", false),
+			},
+		},
+	})
+}
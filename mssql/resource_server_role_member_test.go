@@ -170,6 +170,65 @@ func TestAccServerRoleMember_Local_Basic_Update_Both(t *testing.T) {
 	})
 }
 
+func TestAccServerRoleMember_Local_Authoritative(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleMemberDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRoleMember(t, "local_test_auth", "login", map[string]interface{}{"role_name": "dbcreator", "members": "[\"login_test_0\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleMemberExists("mssql_server_role_member.local_test_auth"),
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test_auth", "authoritative", "false"),
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test_auth", "members.#", "1"),
+				),
+			},
+			{
+				// Members added outside of Terraform are only reported in additive mode
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "ALTER SERVER ROLE [dbcreator] ADD MEMBER [login_test_1]"); err != nil {
+						t.Fatalf("unable to add member: %s", err)
+					}
+				},
+				Config:   testAccCheckServerRoleMember(t, "local_test_auth", "login", map[string]interface{}{"role_name": "dbcreator", "members": "[\"login_test_0\"]"}),
+				PlanOnly: true,
+			},
+			{
+				// and removed in authoritative mode
+				Config: testAccCheckServerRoleMember(t, "local_test_auth", "login", map[string]interface{}{"role_name": "dbcreator", "members": "[\"login_test_0\"]", "authoritative": "true"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleMemberExists("mssql_server_role_member.local_test_auth"),
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test_auth", "members.#", "1"),
+					testAccCheckServerRoleMemberCount("mssql_server_role_member.local_test_auth", 1),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "ALTER SERVER ROLE [dbcreator] ADD MEMBER [login_test_2]"); err != nil {
+						t.Fatalf("unable to add member: %s", err)
+					}
+				},
+				Config: testAccCheckServerRoleMember(t, "local_test_auth", "login", map[string]interface{}{"role_name": "dbcreator", "members": "[\"login_test_0\"]", "authoritative": "true"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test_auth", "members.#", "1"),
+					testAccCheckServerRoleMemberCount("mssql_server_role_member.local_test_auth", 1),
+				),
+			},
+			{
+				// Members listed with another case are matched, not removed as unmanaged
+				Config: testAccCheckServerRoleMember(t, "local_test_auth", "login", map[string]interface{}{"role_name": "dbcreator", "members": "[\"LOGIN_TEST_0\"]", "authoritative": "true"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test_auth", "members.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_role_member.local_test_auth", "members.0", "LOGIN_TEST_0"),
+					testAccCheckServerRoleMemberCount("mssql_server_role_member.local_test_auth", 1),
+				),
+			},
+		},
+	})
+}
+
 func TestAccServerRoleMember_Azure_Basic_Create(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -350,6 +409,7 @@ func testAccCheckServerRoleMember(t *testing.T, name string, login string, data
 				}
 				role_name = "{{ .role_name }}"
 				members = {{ .members }}
+				{{ with .authoritative }}authoritative = {{ . }}{{ end }}
 				depends_on = [mssql_login.{{ .name }}]
 			}`
 
@@ -455,3 +515,26 @@ func testAccCheckServerRoleMemberExists(resource string, checks ...Check) resour
 		return nil
 	}
 }
+
+// testAccCheckServerRoleMemberCount checks the number of members of the role of the resource, managed or not.
+func testAccCheckServerRoleMemberCount(resource string, expected int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		connector, err := getTestConnector(rs.Primary.Attributes)
+		if err != nil {
+			return err
+		}
+		roleName := rs.Primary.Attributes["role_name"]
+		roleMembers, err := connector.GetServerRoleMember(roleName, nil)
+		if err != nil {
+			return fmt.Errorf("expected no error, got %s", err)
+		}
+		if roleMembers == nil || len(roleMembers.Members) != expected {
+			return fmt.Errorf("expected %d members in role [%s], got %v", expected, roleName, roleMembers)
+		}
+		return nil
+	}
+}
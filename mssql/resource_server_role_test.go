@@ -142,6 +142,40 @@ func TestAccServerRole_Local_Basic_Remove_owner(t *testing.T) {
 	})
 }
 
+func TestAccServerRole_Local_Permissions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckServerRoleDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServerRole(t, "local_test_perm", "login", map[string]interface{}{"role_name": "test_role_perm", "permissions": "[\"VIEW SERVER STATE\", \"VIEW ANY DATABASE\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleExists("mssql_server_role.local_test_perm"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_perm", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("mssql_server_role.local_test_perm", "permissions.*", "VIEW SERVER STATE"),
+					resource.TestCheckTypeSetElemAttr("mssql_server_role.local_test_perm", "permissions.*", "VIEW ANY DATABASE"),
+				),
+			},
+			{
+				// Permissions granted outside of the role definition are revoked
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "GRANT VIEW ANY DEFINITION TO [test_role_perm]"); err != nil {
+						t.Fatalf("unable to change permissions: %s", err)
+					}
+				},
+				Config: testAccCheckServerRole(t, "local_test_perm", "login", map[string]interface{}{"role_name": "test_role_perm", "permissions": "[\"VIEW SERVER STATE\"]"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServerRoleExists("mssql_server_role.local_test_perm"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_perm", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("mssql_server_role.local_test_perm", "permissions.0", "VIEW SERVER STATE"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccServerRole_Azure_Basic_Create(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -164,6 +198,7 @@ func testAccCheckServerRole(t *testing.T, name string, login string, data map[st
 				}
 				role_name = "{{ .role_name }}"
 				{{ with .owner_name }}owner_name = "{{ . }}"{{ end }}
+				{{ with .permissions }}permissions = {{ . }}{{ end }}
 			}`
 
 	data["name"] = name
@@ -2,14 +2,17 @@ package mssql
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
 	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 )
 
 func resourceUser() *schema.Resource {
@@ -39,7 +42,6 @@ func resourceUser() *schema.Resource {
 			usernameProp: {
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				ValidateFunc: validate.SQLIdentifier,
 			},
 			objectIdProp: {
@@ -53,21 +55,64 @@ func resourceUser() *schema.Resource {
 				Optional:      true,
 				ForceNew:      true,
 				ValidateFunc:  validation.StringInSlice([]string{"E", "X"}, false),
-				ConflictsWith: []string{loginNameProp, passwordProp},
+				ConflictsWith: []string{loginNameProp, passwordProp, generatePasswordProp},
 				RequiredWith:  []string{objectIdProp},
 			},
 			loginNameProp: {
 				Type:          schema.TypeString,
 				Optional:      true,
+				ConflictsWith: []string{passwordProp, generatePasswordProp, objectIdProp, withoutLoginProp, certificateNameProp, asymmetricKeyNameProp},
+				ValidateFunc:  validate.SQLIdentifier,
+			},
+			withoutLoginProp: {
+				Type:          schema.TypeBool,
+				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{passwordProp, objectIdProp},
+				Default:       false,
+				ConflictsWith: []string{passwordProp, generatePasswordProp, objectIdProp, certificateNameProp, asymmetricKeyNameProp},
+			},
+			certificateNameProp: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{passwordProp, generatePasswordProp, objectIdProp, asymmetricKeyNameProp},
 				ValidateFunc:  validate.SQLIdentifier,
 			},
-			passwordProp: {
-				Type:         schema.TypeString,
+			asymmetricKeyNameProp: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{passwordProp, generatePasswordProp, objectIdProp},
+				ValidateFunc:  validate.SQLIdentifier,
+			},
+			enableContainmentProp: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			migrateToContainedProp: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			remapOrphanProp: {
+				Type:         schema.TypeBool,
 				Optional:     true,
-				Sensitive:    true,
-				ValidateFunc: validate.SQLIdentifierPassword,
+				Default:      false,
+				RequiredWith: []string{loginNameProp},
+			},
+			passwordProp: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{generatePasswordProp},
+				ValidateFunc:  validate.SQLPasswordLength,
+			},
+			generatePasswordProp: getGeneratePasswordSchema(),
+			generatedPasswordProp: {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 			sidStrProp: {
 				Type:     schema.TypeString,
@@ -86,12 +131,17 @@ func resourceUser() *schema.Resource {
 				Optional:     true,
 				Default:      defaultDboPropDefault,
 				ValidateFunc: validate.SQLIdentifier,
+				DiffSuppressFunc: func(k, old, new string, data *schema.ResourceData) bool {
+					// Users mapped to a certificate or an asymmetric key have no default schema
+					return data.Get(certificateNameProp) != "" || data.Get(asymmetricKeyNameProp) != "" || old == new
+				},
 			},
 			defaultLanguageProp: {
 				Type:     schema.TypeString,
 				Optional: true,
 				DiffSuppressFunc: func(k, old, new string, data *schema.ResourceData) bool {
-					return data.Get(authenticationTypeProp) == "INSTANCE" || old == new
+					authType := data.Get(authenticationTypeProp)
+					return authType == "INSTANCE" || authType == "NONE" || old == new
 				},
 			},
 			rolesProp: {
@@ -101,7 +151,24 @@ func resourceUser() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			// Role memberships are left alone when manage_roles is false, so that they can be managed with
+			// mssql_database_role_member.
+			manageRolesProp: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			onDestroyTransferOwnershipToProp: getOnDestroyTransferOwnershipSchema(),
+			onDestroyFailOnOwnedProp:         getOnDestroyFailOnOwnedSchema(),
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffGeneratedPassword,
+			customizeDiffPasswordPolicy(usernameProp),
+			customdiff.ForceNewIf(loginNameProp, func(ctx context.Context, data *schema.ResourceDiff, meta interface{}) bool {
+				return !isLoginRemap(data) && !isContainedMigration(data)
+			}),
+			customizeDiffContainment,
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: defaultTimeout,
 			Read:   defaultTimeout,
@@ -117,6 +184,82 @@ type UserConnector interface {
 	UpdateUser(ctx context.Context, database string, user *model.User) error
 	DeleteUser(ctx context.Context, database, username string) error
 	DatabaseExists(ctx context.Context, database string) (bool, error)
+	EnableContainedDatabase(ctx context.Context, database string) error
+	GetContainmentProblem(ctx context.Context, database string) (string, error)
+	MigrateUserToContained(ctx context.Context, database, username string) error
+	DropStringSplitHelper(ctx context.Context, database string) error
+	OrphanedUsersConnector
+	OwnershipConnector
+}
+
+// isLoginRemap reports whether the login_name change can be applied in place with ALTER USER ... WITH LOGIN. This is
+// the case for users mapped to a server login, and for orphaned users (which read back with an empty login_name) if
+// remap_orphan is set.
+func isLoginRemap(data *schema.ResourceDiff) bool {
+	if data.Get(authenticationTypeProp).(string) != "INSTANCE" {
+		return false
+	}
+	oldName, newName := data.GetChange(loginNameProp)
+	if newName.(string) == "" {
+		return false
+	}
+	return oldName.(string) != "" || data.Get(remapOrphanProp).(bool)
+}
+
+// isContainedMigration reports whether removing login_name converts the user mapped to a login into a contained
+// database user with sp_migrate_user_to_contained.
+func isContainedMigration(data *schema.ResourceDiff) bool {
+	oldName, newName := data.GetChange(loginNameProp)
+	return data.Get(migrateToContainedProp).(bool) && oldName.(string) != "" && newName.(string) == "" &&
+		data.Get(authenticationTypeProp).(string) == "INSTANCE"
+}
+
+// customizeDiffContainment checks at plan time that a contained user can be created, or a user migrated to a contained
+// user, when enable_containment is false. The check is skipped when the server or the database is not known yet or
+// cannot be reached, e.g. because the database is created by the same apply.
+func customizeDiffContainment(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Get(enableContainmentProp).(bool) {
+		return nil
+	}
+	contained := diff.Id() == "" && (!diff.NewValueKnown(passwordProp) || diff.Get(passwordProp).(string) != "" || len(diff.Get(generatePasswordProp).([]interface{})) > 0)
+	if !contained && !isContainedMigration(diff) {
+		return nil
+	}
+	if !diff.NewValueKnown(serverProp) || diff.Get(serverProp+".0.host").(string) == "" || !diff.NewValueKnown(databaseProp) {
+		return nil
+	}
+	provider, ok := meta.(model.Provider)
+	if !ok {
+		return nil
+	}
+	logger := loggerFromMeta(meta, "user", "customizediff")
+	connector, err := provider.GetConnector(serverProp, diff)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Skipping the containment check")
+		return nil
+	}
+	database := diff.Get(databaseProp).(string)
+	problem, err := connector.(UserConnector).GetContainmentProblem(ctx, database)
+	if err != nil {
+		logger.Warn().Err(err).Msgf("Skipping the containment check of database [%s]", database)
+		return nil
+	}
+	if problem != "" {
+		return errors.Errorf("user [%s].[%s] cannot be a contained user: %s, set %s = true or enable it outside of Terraform", database, diff.Get(usernameProp), problem, enableContainmentProp)
+	}
+	return nil
+}
+
+// checkContainment returns an error describing why contained users cannot be created in the database, if they cannot.
+func checkContainment(ctx context.Context, connector UserConnector, database string) error {
+	problem, err := connector.GetContainmentProblem(ctx, database)
+	if err != nil {
+		return err
+	}
+	if problem != "" {
+		return errors.Errorf("%s, set %s = true or enable it outside of Terraform", problem, enableContainmentProp)
+	}
+	return nil
 }
 
 func resourceUserCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -127,28 +270,64 @@ func resourceUserCreate(ctx context.Context, data *schema.ResourceData, meta int
 	username := data.Get(usernameProp).(string)
 	objectId := data.Get(objectIdProp).(string)
 	loginName := data.Get(loginNameProp).(string)
-	password := data.Get(passwordProp).(string)
+	withoutLogin := data.Get(withoutLoginProp).(bool)
+	certificateName := data.Get(certificateNameProp).(string)
+	asymmetricKeyName := data.Get(asymmetricKeyNameProp).(string)
 	typeStr := data.Get(typeStrProp).(string)
 	defaultSchema := data.Get(defaultSchemaProp).(string)
 	defaultLanguage := data.Get(defaultLanguageProp).(string)
-	roles := data.Get(rolesProp).(*schema.Set).List()
+	var roles []string
+	if data.Get(manageRolesProp).(bool) {
+		roles = toStringSlice(data.Get(rolesProp).(*schema.Set).List())
+	}
+
+	password, err := resolvePassword(data, meta, username, true)
+	if err != nil {
+		return diag.FromErr(errors.Wrapf(err, "unable to generate password for user [%s].[%s]", database, username))
+	}
 
 	connector, err := getUserConnector(meta, data)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if password != "" {
+		if data.Get(enableContainmentProp).(bool) {
+			err = connector.EnableContainedDatabase(ctx, database)
+		} else {
+			err = checkContainment(ctx, connector, database)
+		}
+		if err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to create contained user [%s].[%s]", database, username))
+		}
+	}
+
 	user := &model.User{
-		Username:        username,
-		ObjectId:        objectId,
-		LoginName:       loginName,
-		Password:        password,
-		TypeStr:         typeStr,
-		DefaultSchema:   defaultSchema,
-		DefaultLanguage: defaultLanguage,
-		Roles:           toStringSlice(roles),
+		Username:          username,
+		ObjectId:          objectId,
+		LoginName:         loginName,
+		Password:          password,
+		WithoutLogin:      withoutLogin,
+		CertificateName:   certificateName,
+		AsymmetricKeyName: asymmetricKeyName,
+		TypeStr:           typeStr,
+		DefaultSchema:     defaultSchema,
+		DefaultLanguage:   defaultLanguage,
+		Roles:             roles,
+	}
+
+	orphaned := false
+	if data.Get(remapOrphanProp).(bool) {
+		if orphaned, err = isOrphanedUser(ctx, connector, database, username); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to check if user [%s].[%s] is orphaned", database, username))
+		}
 	}
-	if err = connector.CreateUser(ctx, database, user); err != nil {
+	if orphaned {
+		if err = connector.UpdateUser(ctx, database, user); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to remap orphaned user [%s].[%s] to login [%s]", database, username, loginName))
+		}
+		logger.Info().Msgf("remapped orphaned user [%s].[%s] to login [%s]", database, username, loginName)
+	} else if err = connector.CreateUser(ctx, database, user); err != nil {
 		return diag.FromErr(errors.Wrapf(err, "unable to create user [%s].[%s]", database, username))
 	}
 
@@ -156,7 +335,9 @@ func resourceUserCreate(ctx context.Context, data *schema.ResourceData, meta int
 
 	logger.Info().Msgf("created user [%s].[%s]", database, username)
 
-	return resourceUserRead(ctx, data, meta)
+	diags := cleanupStringSplitHelper(ctx, meta, connector, database)
+
+	return append(diags, resourceUserRead(ctx, data, meta)...)
 }
 
 func resourceUserRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -193,6 +374,15 @@ func resourceUserRead(ctx context.Context, data *schema.ResourceData, meta inter
 		if err = data.Set(loginNameProp, user.LoginName); err != nil {
 			return diag.FromErr(err)
 		}
+		if err = data.Set(withoutLoginProp, user.WithoutLogin); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(certificateNameProp, user.CertificateName); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = data.Set(asymmetricKeyNameProp, user.AsymmetricKeyName); err != nil {
+			return diag.FromErr(err)
+		}
 		if err = data.Set(sidStrProp, user.SIDStr); err != nil {
 			return diag.FromErr(err)
 		}
@@ -211,8 +401,10 @@ func resourceUserRead(ctx context.Context, data *schema.ResourceData, meta inter
 		if err = data.Set(defaultLanguageProp, user.DefaultLanguage); err != nil {
 			return diag.FromErr(err)
 		}
-		if err = data.Set(rolesProp, user.Roles); err != nil {
-			return diag.FromErr(err)
+		if data.Get(manageRolesProp).(bool) {
+			if err = data.Set(rolesProp, user.Roles); err != nil {
+				return diag.FromErr(err)
+			}
 		}
 	}
 
@@ -231,12 +423,13 @@ func resourceUserUpdate(ctx context.Context, data *schema.ResourceData, meta int
 
 	// Store old values for all properties that might change
 	oldValues := make(map[string]interface{})
-	for _, prop := range []string{passwordProp, defaultSchemaProp, defaultLanguageProp} {
+	for _, prop := range []string{usernameProp, loginNameProp, passwordProp, generatePasswordProp, defaultSchemaProp, defaultLanguageProp} {
 		if data.HasChange(prop) {
 			oldValue, _ := data.GetChange(prop)
 			oldValues[prop] = oldValue
 		}
 	}
+	oldValues[generatedPasswordProp], _ = data.GetChange(generatedPasswordProp)
 	// Handle roles separately since it's a Set type
 	if data.HasChange(rolesProp) {
 		oldValue, _ := data.GetChange(rolesProp)
@@ -254,21 +447,51 @@ func resourceUserUpdate(ctx context.Context, data *schema.ResourceData, meta int
 		Username:        username,
 		DefaultSchema:   defaultSchema,
 		DefaultLanguage: defaultLanguage,
-		Roles:           toStringSlice(roles),
+	}
+	if data.Get(manageRolesProp).(bool) {
+		user.Roles = toStringSlice(roles)
 	}
 
-	// Only include password in the update if it has changed
-	if data.HasChange(passwordProp) {
-		user.Password = data.Get(passwordProp).(string)
+	// Only include password in the update if it has changed or has to be regenerated
+	if data.HasChanges(passwordProp, generatePasswordProp) {
+		if user.Password, err = resolvePassword(data, meta, username, data.HasChange(generatePasswordProp)); err != nil {
+			return diag.FromErr(errors.Wrapf(err, "unable to generate password for user [%s].[%s]", database, username))
+		}
+	}
+
+	// Rename the user and map it to another login with the same ALTER USER statement
+	if data.HasChange(usernameProp) {
+		oldValue, _ := data.GetChange(usernameProp)
+		user.PreviousUsername = oldValue.(string)
+	}
+	if data.HasChange(loginNameProp) {
+		user.LoginName = data.Get(loginNameProp).(string)
+	}
+
+	// A user mapped to a login is converted to a contained user when login_name is removed
+	if data.HasChange(loginNameProp) && user.LoginName == "" {
+		currentName := username
+		if user.PreviousUsername != "" {
+			currentName = user.PreviousUsername
+		}
+		if data.Get(enableContainmentProp).(bool) {
+			err = connector.EnableContainedDatabase(ctx, database)
+		} else {
+			err = checkContainment(ctx, connector, database)
+		}
+		if err == nil {
+			err = connector.MigrateUserToContained(ctx, database, currentName)
+		}
+		if err != nil {
+			revertUserState(data, logger, oldValues)
+			return diag.FromErr(errors.Wrapf(err, "unable to migrate user [%s].[%s] to a contained user", database, username))
+		}
+		logger.Info().Msgf("migrated user [%s].[%s] to a contained user", database, username)
 	}
 
 	if err = connector.UpdateUser(ctx, database, user); err != nil {
 		// If update fails, revert all changed values in the state
-		for prop, oldValue := range oldValues {
-			if err := data.Set(prop, oldValue); err != nil {
-				logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
-			}
-		}
+		revertUserState(data, logger, oldValues)
 		return diag.FromErr(errors.Wrapf(err, "unable to update user [%s].[%s]", database, username))
 	}
 
@@ -276,7 +499,9 @@ func resourceUserUpdate(ctx context.Context, data *schema.ResourceData, meta int
 
 	logger.Info().Msgf("updated user [%s].[%s]", database, username)
 
-	return resourceUserRead(ctx, data, meta)
+	diags := cleanupStringSplitHelper(ctx, meta, connector, database)
+
+	return append(diags, resourceUserRead(ctx, data, meta)...)
 }
 
 func resourceUserDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -291,6 +516,10 @@ func resourceUserDelete(ctx context.Context, data *schema.ResourceData, meta int
 		return diag.FromErr(err)
 	}
 
+	if diags := releaseOwnedObjects(ctx, data, connector, database, username); diags.HasError() {
+		return diags
+	}
+
 	if err = connector.DeleteUser(ctx, database, username); err != nil {
 		return diag.FromErr(errors.Wrapf(err, "unable to delete user [%s].[%s]", database, username))
 	}
@@ -300,7 +529,7 @@ func resourceUserDelete(ctx context.Context, data *schema.ResourceData, meta int
 	// d.SetId("") is automatically called assuming delete returns no errors, but it is added here for explicitness.
 	data.SetId("")
 
-	return nil
+	return cleanupStringSplitHelper(ctx, meta, connector, database)
 }
 
 func resourceUserImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -354,6 +583,15 @@ func resourceUserImport(ctx context.Context, data *schema.ResourceData, meta int
 	if err = data.Set(principalIdProp, login.PrincipalID); err != nil {
 		return nil, err
 	}
+	if err = data.Set(withoutLoginProp, login.WithoutLogin); err != nil {
+		return nil, err
+	}
+	if err = data.Set(certificateNameProp, login.CertificateName); err != nil {
+		return nil, err
+	}
+	if err = data.Set(asymmetricKeyNameProp, login.AsymmetricKeyName); err != nil {
+		return nil, err
+	}
 	if err = data.Set(defaultSchemaProp, login.DefaultSchema); err != nil {
 		return nil, err
 	}
@@ -363,10 +601,62 @@ func resourceUserImport(ctx context.Context, data *schema.ResourceData, meta int
 	if err = data.Set(rolesProp, login.Roles); err != nil {
 		return nil, err
 	}
+	if err = data.Set(remapOrphanProp, false); err != nil {
+		return nil, err
+	}
+	if err = data.Set(manageRolesProp, true); err != nil {
+		return nil, err
+	}
+	if err = data.Set(onDestroyFailOnOwnedProp, false); err != nil {
+		return nil, err
+	}
+	if err = data.Set(enableContainmentProp, false); err != nil {
+		return nil, err
+	}
+	if err = data.Set(migrateToContainedProp, false); err != nil {
+		return nil, err
+	}
 
 	return []*schema.ResourceData{data}, nil
 }
 
+func revertUserState(data *schema.ResourceData, logger zerolog.Logger, oldValues map[string]interface{}) {
+	for prop, oldValue := range oldValues {
+		if err := data.Set(prop, oldValue); err != nil {
+			logger.Error().Err(err).Msgf("Failed to revert %s state after update error", prop)
+		}
+	}
+}
+
+func isOrphanedUser(ctx context.Context, connector UserConnector, database, username string) (bool, error) {
+	users, err := connector.GetOrphanedUsers(ctx, database)
+	if err != nil {
+		return false, err
+	}
+	for _, user := range users {
+		if user.Username == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cleanupStringSplitHelper drops the [dbo].[String_Split] function that earlier provider versions created in databases
+// with a compatibility level below 130, if the provider is configured to do so. Failures are reported as warnings.
+func cleanupStringSplitHelper(ctx context.Context, meta interface{}, connector UserConnector, database string) diag.Diagnostics {
+	if provider, ok := meta.(model.Provider); !ok || !provider.CleanupStringSplitHelper() {
+		return nil
+	}
+	if err := connector.DropStringSplitHelper(ctx, database); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("unable to drop helper function [%s].[dbo].[String_Split]", database),
+			Detail:   err.Error(),
+		}}
+	}
+	return nil
+}
+
 func getUserConnector(meta interface{}, data *schema.ResourceData) (UserConnector, error) {
 	provider := meta.(model.Provider)
 	connector, err := provider.GetConnector(serverProp, data)
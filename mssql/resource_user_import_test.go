@@ -29,3 +29,26 @@ func TestAccUser_Local_BasicImport(t *testing.T) {
 		},
 	})
 }
+
+func TestAccUser_Local_WithoutLoginImport(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckUser(t, "test_import", "login", map[string]interface{}{"username": "user_without_login_import", "without_login": true}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("mssql_user.test_import"),
+				),
+			},
+			{
+				ResourceName:      "mssql_user.test_import",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateId("mssql_user.test_import", false),
+			},
+		},
+	})
+}
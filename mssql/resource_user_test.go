@@ -186,6 +186,34 @@ func TestAccUser_Azure_Database_Update_Password(t *testing.T) {
 	})
 }
 
+func TestAccUser_Azure_Database_GeneratePassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckUser(t, "azure_generate", "azure", map[string]interface{}{"database": "testdb", "username": "test_generate_password", "generate_password": "{\n keepers = { rotation = \"1\" }\n }"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("mssql_user.azure_generate", Check{"authentication_type", "==", "DATABASE"}),
+					testAccCheckGeneratedPasswordUserWorks("mssql_user.azure_generate", "test_generate_password"),
+					resource.TestCheckNoResourceAttr("mssql_user.azure_generate", "password"),
+					resource.TestCheckResourceAttr("mssql_user.azure_generate", "generate_password.0.length", "32"),
+					resource.TestMatchResourceAttr("mssql_user.azure_generate", "generated_password", regexp.MustCompile("^.{32}$")),
+				),
+			},
+			{
+				Config: testAccCheckUser(t, "azure_generate", "azure", map[string]interface{}{"database": "testdb", "username": "test_generate_password", "generate_password": "{\n keepers = { rotation = \"2\" }\n }"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("mssql_user.azure_generate"),
+					testAccCheckGeneratedPasswordUserWorks("mssql_user.azure_generate", "test_generate_password"),
+					resource.TestCheckResourceAttr("mssql_user.azure_generate", "generate_password.0.keepers.rotation", "2"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccUser_Azure_Database_Pass_Validate(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
@@ -429,6 +457,331 @@ func TestAccUser_Local_Update_Roles(t *testing.T) {
 					testAccCheckDatabaseUserWorks("mssql_user.update", "user_update", "valueIsH8kd$¡"),
 				),
 			},
+			{
+				// Removing roles from the configuration removes every membership
+				Config: testAccCheckUser(t, "update", "login", map[string]interface{}{"username": "test_update", "login_name": "user_update", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.update", "roles.#", "0"),
+					testAccCheckUserExists("mssql_user.update", Check{"roles", "==", []string{}}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_Update_Rename(t *testing.T) {
+	// The principal id does not change when the user is altered in place
+	var principalId string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckUser(t, "rename", "login", map[string]interface{}{"username": "test_rename", "login_name": "user_rename", "login_password": "valueIsH8kd$¡", "roles": `["db_datareader"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("mssql_user.rename", Check{"login_name", "==", "user_rename"}),
+					testAccStoreResourceAttr("mssql_user.rename", "principal_id", &principalId),
+				),
+			},
+			{
+				Config: testAccCheckUser(t, "rename", "login", map[string]interface{}{"username": "test_renamed", "login_name": "user_rename", "login_password": "valueIsH8kd$¡", "roles": `["db_datareader"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.rename", "id", "sqlserver://localhost:1433/master/user/test_renamed"),
+					resource.TestCheckResourceAttr("mssql_user.rename", "username", "test_renamed"),
+					resource.TestCheckResourceAttrPtr("mssql_user.rename", "principal_id", &principalId),
+					testAccCheckUserExists("mssql_user.rename", Check{"login_name", "==", "user_rename"}, Check{"roles", "==", []string{"db_datareader"}}),
+					testAccCheckDatabaseUserWorks("mssql_user.rename", "user_rename", "valueIsH8kd$¡"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_Update_LoginName(t *testing.T) {
+	// The principal id does not change when the user is altered in place
+	var principalId string
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckUser(t, "remap", "login", map[string]interface{}{"username": "test_remap", "login_name": "user_remap", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("mssql_user.remap", Check{"login_name", "==", "user_remap"}),
+					testAccStoreResourceAttr("mssql_user.remap", "principal_id", &principalId),
+				),
+			},
+			{
+				Config: testAccCheckUser(t, "remap", "login", map[string]interface{}{"username": "test_remap", "login_name": "user_remapped", "login_password": "valueIsH8kd$¡"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.remap", "login_name", "user_remapped"),
+					resource.TestCheckResourceAttrPtr("mssql_user.remap", "principal_id", &principalId),
+					testAccCheckUserExists("mssql_user.remap", Check{"login_name", "==", "user_remapped"}),
+					testAccCheckDatabaseUserWorks("mssql_user.remap", "user_remapped", "valueIsH8kd$¡"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_WithoutLogin(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckUser(t, "without_login", "login", map[string]interface{}{"username": "test_without_login", "without_login": true, "roles": `["db_datareader"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.without_login", "without_login", "true"),
+					resource.TestCheckResourceAttr("mssql_user.without_login", "type", "S"),
+					resource.TestCheckResourceAttr("mssql_user.without_login", "authentication_type", "NONE"),
+					resource.TestCheckResourceAttr("mssql_user.without_login", "login_name", ""),
+					testAccCheckUserExists("mssql_user.without_login", Check{"authentication_type", "==", "NONE"}, Check{"roles", "==", []string{"db_datareader"}}),
+				),
+			},
+			{
+				Config: testAccCheckUser(t, "without_login", "login", map[string]interface{}{"username": "test_without_login", "without_login": true, "default_schema": "sys"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("mssql_user.without_login", Check{"default_schema", "==", "sys"}, Check{"roles", "==", []string{}}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_Certificate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccCheckUserDestroy(state); err != nil {
+				return err
+			}
+			return testAccExecuteLocalScript("master", "DROP CERTIFICATE [test_user_certificate]")
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					script := "CREATE CERTIFICATE [test_user_certificate] ENCRYPTION BY PASSWORD = 'valueIsH8kd$¡' WITH SUBJECT = 'test_user_certificate'"
+					if err := testAccExecuteLocalScript("master", script); err != nil {
+						t.Fatalf("unable to create certificate: %s", err)
+					}
+				},
+				Config: testAccCheckUser(t, "certificate", "login", map[string]interface{}{"username": "test_certificate", "certificate_name": "test_user_certificate", "roles": `["db_datareader"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.certificate", "certificate_name", "test_user_certificate"),
+					resource.TestCheckResourceAttr("mssql_user.certificate", "type", "C"),
+					resource.TestCheckResourceAttr("mssql_user.certificate", "default_schema", ""),
+					testAccCheckUserExists("mssql_user.certificate", Check{"roles", "==", []string{"db_datareader"}}),
+				),
+			},
+			{
+				ResourceName:      "mssql_user.certificate",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateId("mssql_user.certificate", false),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_Contained(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckContainedUser(t, "contained", map[string]interface{}{"password": "valueIsH8kd$¡"}),
+				ExpectError: regexp.MustCompile("(contained database authentication is disabled on the server|is not partially contained), set enable_containment = true"),
+			},
+			{
+				// Once the database exists, the missing containment is reported at plan time
+				Config:      testAccCheckContainedUser(t, "contained", map[string]interface{}{"password": "valueIsH8kd$¡"}),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`user \[tf_acc_test_contained\]\.\[test_contained\] cannot be a contained user`),
+			},
+			{
+				Config: testAccCheckContainedUser(t, "contained", map[string]interface{}{"password": "valueIsH8kd$¡", "enable_containment": true}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.contained", "authentication_type", "DATABASE"),
+					resource.TestCheckResourceAttr("mssql_user.contained", "enable_containment", "true"),
+					testAccCheckUserExists("mssql_user.contained", Check{"authentication_type", "==", "DATABASE"}),
+					testAccCheckDatabaseUserWorks("mssql_user.contained", "test_contained", "valueIsH8kd$¡"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_MigrateToContained(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckContainedUser(t, "migrate", map[string]interface{}{"login_name": "user_migrate", "enable_containment": true}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.migrate", "authentication_type", "INSTANCE"),
+					testAccCheckUserExists("mssql_user.migrate", Check{"login_name", "==", "user_migrate"}),
+				),
+			},
+			{
+				Config: testAccCheckContainedUser(t, "migrate", map[string]interface{}{"login_name": "user_migrate", "keep_login": true, "enable_containment": true, "migrate_to_contained": true}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.migrate", "authentication_type", "DATABASE"),
+					resource.TestCheckResourceAttr("mssql_user.migrate", "login_name", ""),
+					testAccCheckUserExists("mssql_user.migrate", Check{"authentication_type", "==", "DATABASE"}, Check{"login_name", "==", ""}),
+					testAccCheckDatabaseUserWorks("mssql_user.migrate", "test_migrate", "valueIsH8kd$¡"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_RemapOrphan(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testAccCreateOrphanedUser(t, "master", "test_remap_orphan") },
+				Config:    testAccCheckUser(t, "remap_orphan", "login", map[string]interface{}{"username": "test_remap_orphan", "login_name": "user_remap_orphan", "login_password": "valueIsH8kd$¡", "remap_orphan": true, "roles": `["db_datareader"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.remap_orphan", "login_name", "user_remap_orphan"),
+					resource.TestCheckResourceAttr("mssql_user.remap_orphan", "remap_orphan", "true"),
+					testAccCheckUserExists("mssql_user.remap_orphan", Check{"login_name", "==", "user_remap_orphan"}, Check{"roles", "==", []string{"db_datareader"}}),
+					testAccCheckDatabaseUserWorks("mssql_user.remap_orphan", "user_remap_orphan", "valueIsH8kd$¡"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_RemapOrphan_Existing(t *testing.T) {
+	config := map[string]interface{}{"username": "test_remap_existing", "login_name": "user_remap_existing", "login_password": "valueIsH8kd$¡", "remap_orphan": true}
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckUser(t, "remap_existing", "login", config),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("mssql_user.remap_existing", Check{"login_name", "==", "user_remap_existing"}),
+				),
+			},
+			{
+				// Dropping the login orphans the user, which is remapped in place to the recreated login
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "DROP LOGIN [user_remap_existing]"); err != nil {
+						t.Fatalf("unable to drop login: %s", err)
+					}
+				},
+				Config: testAccCheckUser(t, "remap_existing", "login", config),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.remap_existing", "login_name", "user_remap_existing"),
+					testAccCheckUserExists("mssql_user.remap_existing", Check{"login_name", "==", "user_remap_existing"}),
+					testAccCheckDatabaseUserWorks("mssql_user.remap_existing", "user_remap_existing", "valueIsH8kd$¡"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_CleanupStringSplitHelper(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testAccCreateStringSplitHelper(t, "master") },
+				Config: `provider "mssql" {
+							cleanup_string_split_helper = true
+						}
+						` + testAccCheckUser(t, "cleanup_helper", "login", map[string]interface{}{"username": "test_cleanup_helper", "without_login": true, "roles": `["db_datareader"]`}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("mssql_user.cleanup_helper", Check{"roles", "==", []string{"db_datareader"}}),
+					testAccCheckStringSplitHelperNotExists("master"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_OnDestroyTransferOwnership(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy: func(state *terraform.State) error {
+			if err := testAccCheckUserDestroy(state); err != nil {
+				return err
+			}
+			if err := testAccDropOwnedSchema("master", "test_transfer_schema", "test_transfer_target"); err != nil {
+				return err
+			}
+			return testAccExecuteLocalScript("master", "DROP USER [test_transfer_target]")
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := testAccExecuteLocalScript("master", "CREATE USER [test_transfer_target] WITHOUT LOGIN"); err != nil {
+						t.Fatalf("unable to create user: %s", err)
+					}
+				},
+				Config: testAccCheckUser(t, "transfer_ownership", "login", map[string]interface{}{"username": "test_transfer_ownership", "without_login": true, "on_destroy_transfer_ownership_to": "test_transfer_target"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.transfer_ownership", "on_destroy_transfer_ownership_to", "test_transfer_target"),
+					testAccCreateOwnedSchema("master", "test_transfer_schema", "test_transfer_ownership"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_Local_OnDestroyFailOnOwnedObjects(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IsUnitTest:        runLocalAccTests,
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      func(state *terraform.State) error { return testAccCheckUserDestroy(state) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckUser(t, "fail_on_owned", "login", map[string]interface{}{"username": "test_fail_on_owned", "without_login": true, "on_destroy_fail_on_owned_objects": true}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mssql_user.fail_on_owned", "on_destroy_fail_on_owned_objects", "true"),
+					testAccCreateOwnedSchema("master", "test_fail_on_owned_schema", "test_fail_on_owned"),
+				),
+			},
+			{
+				Config:      testAccCheckUser(t, "fail_on_owned", "login", map[string]interface{}{"username": "test_fail_on_owned", "without_login": true, "on_destroy_fail_on_owned_objects": true}),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`owns 1 object\(s\)`),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccDropOwnedSchema("master", "test_fail_on_owned_schema", "test_fail_on_owned"); err != nil {
+						t.Fatalf("unable to drop schema: %s", err)
+					}
+				},
+				Config: testAccCheckUser(t, "fail_on_owned", "login", map[string]interface{}{"username": "test_fail_on_owned", "without_login": true, "on_destroy_fail_on_owned_objects": true}),
+			},
 		},
 	})
 }
@@ -541,10 +894,17 @@ func testAccCheckUser(t *testing.T, name string, login string, data map[string]i
 				{{ with .database }}database = "{{ . }}"{{ end }}
 				username = "{{ .username }}"
 				{{ with .password }}password = "{{ . }}"{{ end }}
+				{{ with .generate_password }}generate_password {{ . }}{{ end }}
 				{{ with .login_name }}login_name = "{{ . }}"{{ end }}
 				{{ with .default_schema }}default_schema = "{{ . }}"{{ end }}
 				{{ with .default_language }}default_language = "{{ . }}"{{ end }}
 				{{ with .roles }}roles = {{ . }}{{ end }}
+				{{ with .remap_orphan }}remap_orphan = {{ . }}{{ end }}
+				{{ with .without_login }}without_login = {{ . }}{{ end }}
+				{{ with .certificate_name }}certificate_name = "{{ . }}"{{ end }}
+				{{ with .asymmetric_key_name }}asymmetric_key_name = "{{ . }}"{{ end }}
+				{{ with .on_destroy_transfer_ownership_to }}on_destroy_transfer_ownership_to = "{{ . }}"{{ end }}
+				{{ with .on_destroy_fail_on_owned_objects }}on_destroy_fail_on_owned_objects = {{ . }}{{ end }}
 				{{ if .login_name }}
 				depends_on = [mssql_login.{{ .name }}]
 				{{ end }}
@@ -567,6 +927,48 @@ func testAccCheckUser(t *testing.T, name string, login string, data map[string]i
 	return res
 }
 
+// testAccCheckContainedUser creates a user in a new database. If keep_login is set, the login is kept but the user is
+// not mapped to it.
+func testAccCheckContainedUser(t *testing.T, name string, data map[string]interface{}) string {
+	text := `resource "mssql_database" "{{ .name }}" {
+				server {
+					host = "localhost"
+					login {}
+				}
+				database_name = "tf_acc_test_{{ .name }}"
+			}
+			{{ if .login_name }}
+			resource "mssql_login" "{{ .name }}" {
+				server {
+					host = "localhost"
+					login {}
+				}
+				login_name = "{{ .login_name }}"
+				password   = "valueIsH8kd$¡"
+			}
+			{{ end }}
+			resource "mssql_user" "{{ .name }}" {
+				server {
+					host = "localhost"
+					login {}
+				}
+				database = mssql_database.{{ .name }}.database_name
+				username = "test_{{ .name }}"
+				{{ with .password }}password = "{{ . }}"{{ end }}
+				{{ if and .login_name (not .keep_login) }}login_name = mssql_login.{{ .name }}.login_name{{ end }}
+				{{ with .enable_containment }}enable_containment = {{ . }}{{ end }}
+				{{ with .migrate_to_contained }}migrate_to_contained = {{ . }}{{ end }}
+				{{ if .login_name }}depends_on = [mssql_login.{{ .name }}]{{ end }}
+			}`
+
+	data["name"] = name
+	res, err := templateToString(name, text, data)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	return res
+}
+
 func testAccCheckMultipleUsers(t *testing.T, name string, login string, data map[string]interface{}, count int) string {
 	text := `{{ if .login_name }}
 			resource "mssql_login" "{{ .name }}" {
@@ -722,6 +1124,16 @@ func testAccCheckDatabaseUserWorks(resource string, username, password string) r
 	}
 }
 
+func testAccCheckGeneratedPasswordUserWorks(resource string, username string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		return testAccCheckDatabaseUserWorks(resource, username, rs.Primary.Attributes[generatedPasswordProp])(state)
+	}
+}
+
 func testAccCheckExternalUserWorks(resource string, tenantId, clientId, clientSecret string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		rs, ok := state.RootModule().Resources[resource]
@@ -777,3 +1189,52 @@ func getMultipleUsersExistAccCheck(count int) []resource.TestCheckFunc {
 	}
 	return checkFuncs
 }
+
+func testAccStoreResourceAttr(resource, key string, value *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("not found: %s", resource)
+		}
+		*value = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+// testAccCreateStringSplitHelper creates the helper function that earlier provider versions created for user roles.
+func testAccCreateStringSplitHelper(t *testing.T, database string) {
+	script := `IF OBJECT_ID('[dbo].[String_Split]') IS NULL
+		EXEC('CREATE FUNCTION [dbo].[String_Split] (@string nvarchar(max), @delimiter nvarchar(max))
+			/* The same as STRING_SPLIT for compatibility level < 130 */
+			RETURNS TABLE AS RETURN
+			(
+				SELECT Split.a.value(''.'', ''NVARCHAR(MAX)'') AS value
+				FROM (SELECT CAST(''<X>'' + REPLACE(@string, @delimiter, ''</X><X>'') + ''</X>'' AS XML) AS String) AS a
+				CROSS APPLY String.nodes(''/X'') AS Split(a)
+			)')`
+	if err := testAccExecuteLocalScript(database, script); err != nil {
+		t.Fatalf("unable to create String_Split helper: %s", err)
+	}
+}
+
+func testAccCheckStringSplitHelperNotExists(database string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		return testAccExecuteLocalScript(database, `IF OBJECT_ID('[dbo].[String_Split]') IS NOT NULL THROW 50000, 'helper function [dbo].[String_Split] still exists', 1`)
+	}
+}
+
+func testAccCreateOwnedSchema(database, schemaName, owner string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		return testAccExecuteLocalScript(database, fmt.Sprintf("EXEC('CREATE SCHEMA [%s] AUTHORIZATION [%s]')", schemaName, owner))
+	}
+}
+
+// testAccDropOwnedSchema drops the schema after checking that it is owned by owner.
+func testAccDropOwnedSchema(database, schemaName, owner string) error {
+	return testAccExecuteLocalScript(database, fmt.Sprintf(`IF SCHEMA_ID('%[1]s') IS NOT NULL
+		BEGIN
+			IF NOT EXISTS (SELECT 1 FROM [sys].[schemas] WHERE name = '%[1]s' AND principal_id = DATABASE_PRINCIPAL_ID('%[2]s'))
+				THROW 50000, 'schema [%[1]s] is not owned by [%[2]s]', 1;
+			DROP SCHEMA [%[1]s]
+		END`, schemaName, owner))
+}
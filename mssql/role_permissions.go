@@ -0,0 +1,185 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/ValeruS/terraform-provider-mssql/mssql/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+)
+
+// roleSecurableTypes are the securable types of the permissions block of mssql_database_role.
+var roleSecurableTypes = []string{"DATABASE", "SCHEMA", "OBJECT"}
+
+type DatabaseRolePermissionsConnector interface {
+	GetDatabaseRolePermissions(ctx context.Context, database, roleName string) ([]model.RolePermission, error)
+	UpdateDatabasePermissions(ctx context.Context, database string, username string, permissions []string, changeType string) error
+	UpdateObjectPermissions(ctx context.Context, database, principalName string, securable model.Securable, permissions []string, changeType string) error
+}
+
+// getDatabaseRolePermissionsSchema returns the permissions block of the database role resource, or its computed
+// counterpart for the data source.
+func getDatabaseRolePermissionsSchema(computed bool) *schema.Schema {
+	if computed {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					permissionProp:      {Type: schema.TypeString, Computed: true},
+					securableTypeProp:   {Type: schema.TypeString, Computed: true},
+					securableSchemaProp: {Type: schema.TypeString, Computed: true},
+					securableNameProp:   {Type: schema.TypeString, Computed: true},
+				},
+			},
+		}
+	}
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				permissionProp: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validate.SQLIdentifierPermission,
+				},
+				securableTypeProp: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "DATABASE",
+					ValidateFunc: validation.StringInSlice(roleSecurableTypes, false),
+				},
+				securableSchemaProp: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				securableNameProp: {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// customizeDiffDatabaseRolePermissions fails if the securable of a permission is not set as its type requires, or if
+// the permission does not apply to the securable type according to the permission catalog of the server.
+func customizeDiffDatabaseRolePermissions(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown(permissionsProp) {
+		return nil
+	}
+	permissions := rolePermissionsFromSet(diff.Get(permissionsProp).(*schema.Set))
+	for _, permission := range permissions {
+		securable := permission.Securable
+		switch {
+		case securable.Type == "DATABASE" && (securable.Schema != "" || securable.Name != ""):
+			return errors.Errorf("%s and %s cannot be set for permission %s on the DATABASE", securableSchemaProp, securableNameProp, permission.Permission)
+		case securable.Type != "DATABASE" && securable.Name == "":
+			return errors.Errorf("%s is required for permission %s on a %s", securableNameProp, permission.Permission, securable.Type)
+		case securable.Type == "SCHEMA" && securable.Schema != "":
+			return errors.Errorf("%s cannot be set for permission %s on a SCHEMA", securableSchemaProp, permission.Permission)
+		}
+	}
+
+	if !diff.HasChange(permissionsProp) {
+		return nil
+	}
+	catalogPermissions := make([]catalogPermission, 0, len(permissions))
+	for _, permission := range permissions {
+		catalogPermissions = append(catalogPermissions, catalogPermission{class: permission.Securable.Type, name: permission.Permission})
+	}
+	return checkCatalogPermissions(ctx, diff, meta, catalogPermissions)
+}
+
+func rolePermissionsFromSet(set *schema.Set) []model.RolePermission {
+	permissions := make([]model.RolePermission, 0, set.Len())
+	for _, item := range set.List() {
+		item := item.(map[string]interface{})
+		permissions = append(permissions, model.RolePermission{
+			Permission: item[permissionProp].(string),
+			Securable: model.Securable{
+				Type:   item[securableTypeProp].(string),
+				Schema: item[securableSchemaProp].(string),
+				Name:   item[securableNameProp].(string),
+			},
+		})
+	}
+	return permissions
+}
+
+// rolePermissionKey identifies a permission regardless of case and of the default dbo schema of objects.
+func rolePermissionKey(permission model.RolePermission) string {
+	securableSchema := permission.Securable.Schema
+	if permission.Securable.Type == "OBJECT" && securableSchema == "" {
+		securableSchema = defaultDboPropDefault
+	}
+	return strings.ToUpper(fmt.Sprintf("%s|%s|%s|%s", permission.Permission, permission.Securable.Type, securableSchema, permission.Securable.Name))
+}
+
+// flattenRolePermissions returns current in the form of the permissions block, keeping the form of the configured
+// permissions so that an omitted dbo schema does not show up as a difference.
+func flattenRolePermissions(current, configured []model.RolePermission) []map[string]interface{} {
+	configuredByKey := make(map[string]model.RolePermission, len(configured))
+	for _, permission := range configured {
+		configuredByKey[rolePermissionKey(permission)] = permission
+	}
+	result := make([]map[string]interface{}, 0, len(current))
+	for _, permission := range current {
+		if c, ok := configuredByKey[rolePermissionKey(permission)]; ok {
+			permission = c
+		}
+		result = append(result, map[string]interface{}{
+			permissionProp:      permission.Permission,
+			securableTypeProp:   permission.Securable.Type,
+			securableSchemaProp: permission.Securable.Schema,
+			securableNameProp:   permission.Securable.Name,
+		})
+	}
+	return result
+}
+
+// diffRolePermissions returns the permissions of newPermissions missing from oldPermissions and the other way around.
+func diffRolePermissions(oldPermissions, newPermissions []model.RolePermission) (toGrant, toRevoke []model.RolePermission) {
+	oldKeys := make(map[string]struct{}, len(oldPermissions))
+	for _, permission := range oldPermissions {
+		oldKeys[rolePermissionKey(permission)] = struct{}{}
+	}
+	newKeys := make(map[string]struct{}, len(newPermissions))
+	for _, permission := range newPermissions {
+		newKeys[rolePermissionKey(permission)] = struct{}{}
+		if _, ok := oldKeys[rolePermissionKey(permission)]; !ok {
+			toGrant = append(toGrant, permission)
+		}
+	}
+	for _, permission := range oldPermissions {
+		if _, ok := newKeys[rolePermissionKey(permission)]; !ok {
+			toRevoke = append(toRevoke, permission)
+		}
+	}
+	return toGrant, toRevoke
+}
+
+// updateDatabaseRolePermissions applies changeType (GRANT or REVOKE) for every permission to the role.
+func updateDatabaseRolePermissions(ctx context.Context, connector DatabaseRolePermissionsConnector, database, roleName string, permissions []model.RolePermission, changeType string) error {
+	for _, permission := range permissions {
+		securable := permission.Securable
+		var err error
+		if securable.Type == "DATABASE" {
+			err = connector.UpdateDatabasePermissions(ctx, database, roleName, []string{permission.Permission}, changeType)
+		} else {
+			if securable.Type == "OBJECT" && securable.Schema == "" {
+				securable.Schema = defaultDboPropDefault
+			}
+			err = connector.UpdateObjectPermissions(ctx, database, roleName, securable, []string{permission.Permission}, changeType)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "unable to %s [%s] on %s to role [%s].[%s]", changeType, permission.Permission, securableString(securable), database, roleName)
+		}
+	}
+	return nil
+}
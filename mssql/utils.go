@@ -3,6 +3,7 @@ package mssql
 import (
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -40,6 +41,14 @@ func getDatabaseRoleID(data *schema.ResourceData) string {
 	return fmt.Sprintf("sqlserver://%s:%s/%s/role/%s", host, port, database, roleName)
 }
 
+func getApplicationRoleID(data *schema.ResourceData) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	return fmt.Sprintf("sqlserver://%s:%s/%s/application_role/%s", host, port, database, roleName)
+}
+
 func getDatabaseSchemaID(data *schema.ResourceData) string {
 	host := data.Get(serverProp + ".0.host").(string)
 	port := data.Get(serverProp + ".0.port").(string)
@@ -102,6 +111,101 @@ func getServerRoleMemberID(data *schema.ResourceData) string {
 	return fmt.Sprintf("sqlserver://%s:%s/role_member/%s", host, port, roleName)
 }
 
+func getDatabaseRoleMemberID(data *schema.ResourceData) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	database := data.Get(databaseProp).(string)
+	roleName := data.Get(roleNameProp).(string)
+	return fmt.Sprintf("sqlserver://%s:%s/%s/role_member/%s", host, port, database, roleName)
+}
+
+func getServerPermissionsID(data *schema.ResourceData) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	principalName := data.Get(principalNameProp).(string)
+	return fmt.Sprintf("sqlserver://%s:%s/server_permissions/%s", host, port, principalName)
+}
+
+func getServerPrincipalsID(data *schema.ResourceData, kind string) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	return fmt.Sprintf("sqlserver://%s:%s/%s", host, port, kind)
+}
+
+func getDatabasePrincipalsID(data *schema.ResourceData, kind string) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	database := data.Get(databaseProp).(string)
+	return fmt.Sprintf("sqlserver://%s:%s/%s/%s", host, port, database, kind)
+}
+
+func getMultiDatabaseUserID(data *schema.ResourceData) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	username := data.Get(usernameProp).(string)
+	return fmt.Sprintf("sqlserver://%s:%s/multi_database_user/%s", host, port, username)
+}
+
+func getMultiDatabasePermissionsID(data *schema.ResourceData) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	username := data.Get(usernameProp).(string)
+	return fmt.Sprintf("sqlserver://%s:%s/multi_database_permissions/%s", host, port, username)
+}
+
+func getObjectPermissionsID(data *schema.ResourceData) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	database := data.Get(databaseProp).(string)
+	principalName := data.Get(principalNameProp).(string)
+	securableType := strings.ReplaceAll(data.Get(securableTypeProp).(string), " ", "_")
+	securable := data.Get(securableNameProp).(string)
+	if securableSchema := data.Get(securableSchemaProp).(string); securableSchema != "" {
+		securable = securableSchema + "/" + securable
+	}
+	return fmt.Sprintf("sqlserver://%s:%s/%s/object_permission/%s/%s/%s", host, port, database, principalName, securableType, securable)
+}
+
+func getEffectivePermissionsID(data *schema.ResourceData) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	database := data.Get(databaseProp).(string)
+	principal := "user/" + data.Get(usernameProp).(string)
+	if loginName := data.Get(loginNameProp).(string); loginName != "" {
+		principal = "login/" + loginName
+	}
+	securable := strings.ReplaceAll(data.Get(securableTypeProp).(string), " ", "_")
+	if securableSchema := data.Get(securableSchemaProp).(string); securableSchema != "" {
+		securable += "/" + securableSchema
+	}
+	if securableName := data.Get(securableNameProp).(string); securableName != "" {
+		securable += "/" + securableName
+	}
+	return fmt.Sprintf("sqlserver://%s:%s/%s/effective_permissions/%s/%s", host, port, database, principal, securable)
+}
+
+func getRoleMembershipGraphID(data *schema.ResourceData) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	id := fmt.Sprintf("sqlserver://%s:%s/role_membership_graph", host, port)
+	if data.Get(scopeProp).(string) == "DATABASE" {
+		id = fmt.Sprintf("sqlserver://%s:%s/%s/role_membership_graph", host, port, data.Get(databaseProp).(string))
+	}
+	if roleName := data.Get(roleNameProp).(string); roleName != "" {
+		id += "/" + roleName
+	}
+	return id
+}
+
+func getOrphanedUsersID(data *schema.ResourceData) string {
+	host := data.Get(serverProp + ".0.host").(string)
+	port := data.Get(serverProp + ".0.port").(string)
+	if database := data.Get(databaseProp).(string); database != "" {
+		return fmt.Sprintf("sqlserver://%s:%s/%s/orphaned_users", host, port, database)
+	}
+	return fmt.Sprintf("sqlserver://%s:%s/orphaned_users", host, port)
+}
+
 func loggerFromMeta(meta interface{}, resource, function string) zerolog.Logger {
 	return meta.(model.Provider).ResourceLogger(resource, function)
 }
@@ -114,6 +218,31 @@ func toStringSlice(values []interface{}) []string {
 	return result
 }
 
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// equalFoldSets reports whether both lists contain the same values, ignoring case and order.
+func equalFoldSets(a, b []string) bool {
+	set := make(map[string]struct{}, len(a))
+	for _, v := range a {
+		set[strings.ToLower(v)] = struct{}{}
+	}
+	other := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		if _, ok := set[strings.ToLower(v)]; !ok {
+			return false
+		}
+		other[strings.ToLower(v)] = struct{}{}
+	}
+	return len(set) == len(other)
+}
+
 func equal(a, b interface{}) bool {
 	switch a.(type) {
 	case []string:
@@ -157,3 +286,16 @@ func stringSetDiff(oldSet, newSet *schema.Set) (toAdd, toRemove []string) {
 	}
 	return toAdd, toRemove
 }
+
+// managedPermissions returns the permissions of current that are managed by the resource: all of them except
+// an unmanaged implicit permission in authoritative mode, otherwise only those in managed.
+func managedPermissions(current []string, managed *schema.Set, authoritative bool, implicit string) []string {
+	result := make([]string, 0, len(current))
+	for _, p := range current {
+		isManaged := managed != nil && managed.Contains(p)
+		if isManaged || (authoritative && p != implicit) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
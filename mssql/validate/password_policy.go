@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	PasswordClassUpper   = "upper"
+	PasswordClassLower   = "lower"
+	PasswordClassNumeric = "numeric"
+	PasswordClassSpecial = "special"
+)
+
+var (
+	PasswordClasses = []string{PasswordClassUpper, PasswordClassLower, PasswordClassNumeric, PasswordClassSpecial}
+
+	passwordClassPatterns = map[string]*regexp.Regexp{
+		PasswordClassUpper:   regexp.MustCompile(`[A-Z]`),
+		PasswordClassLower:   regexp.MustCompile(`[a-z]`),
+		PasswordClassNumeric: regexp.MustCompile(`[0-9]`),
+		PasswordClassSpecial: regexp.MustCompile(`[\W]`),
+	}
+	passwordClassNames = map[string]string{
+		PasswordClassUpper:   "uppercase letters",
+		PasswordClassLower:   "lowercase letters",
+		PasswordClassNumeric: "numbers",
+		PasswordClassSpecial: "non-alphanumeric characters",
+	}
+	countWords = []string{"zero", "one", "two", "three", "four"}
+)
+
+// PasswordPolicy describes the complexity rules passwords of logins, contained users and master keys must follow.
+type PasswordPolicy struct {
+	MinLength           int
+	MaxLength           int
+	MinClasses          int
+	RequiredClasses     []string
+	ForbiddenSubstrings []string
+	ForbidName          bool
+	Dictionary          map[string]struct{}
+}
+
+// DefaultPasswordPolicy matches the SQL Server password complexity rules: 8 to 128 characters from three of the four
+// character classes.
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength:  8,
+		MaxLength:  128,
+		MinClasses: 3,
+	}
+}
+
+// PasswordClassesOf returns the character classes present in v.
+func PasswordClassesOf(v string) map[string]bool {
+	classes := make(map[string]bool, len(PasswordClasses))
+	for _, class := range PasswordClasses {
+		if passwordClassPatterns[class].MatchString(v) {
+			classes[class] = true
+		}
+	}
+	return classes
+}
+
+// Validate checks v against the policy. name is the principal owning the password and is only used when ForbidName is set.
+func (p *PasswordPolicy) Validate(v, k, name string) (errors []error) {
+	if len(v) < p.MinLength {
+		errors = append(errors, fmt.Errorf("length should equal to or greater than %d, got %d", p.MinLength, len(v)))
+		return
+	}
+
+	if len(v) > p.MaxLength {
+		errors = append(errors, fmt.Errorf("length should be equal to or less than %d, got %d", p.MaxLength, len(v)))
+		return
+	}
+
+	classes := PasswordClassesOf(v)
+	if len(classes) < p.MinClasses {
+		errors = append(errors, fmt.Errorf("%q must contain characters from %s of the categories - uppercase letters, lowercase letters, numbers and non-alphanumeric characters", k, countWords[p.MinClasses]))
+	}
+	for _, class := range p.RequiredClasses {
+		if !classes[class] {
+			errors = append(errors, fmt.Errorf("%q must contain %s", k, passwordClassNames[class]))
+		}
+	}
+
+	lower := strings.ToLower(v)
+	for _, s := range p.ForbiddenSubstrings {
+		if s != "" && strings.Contains(lower, strings.ToLower(s)) {
+			errors = append(errors, fmt.Errorf("%q must not contain %q", k, s))
+		}
+	}
+	if p.ForbidName && name != "" && strings.Contains(lower, strings.ToLower(name)) {
+		errors = append(errors, fmt.Errorf("%q must not contain the principal name %q", k, name))
+	}
+	if _, ok := p.Dictionary[lower]; ok {
+		errors = append(errors, fmt.Errorf("%q must not be a dictionary password", k))
+	}
+
+	return
+}
+
+// LoadPasswordDictionary reads disallowed passwords from path, one per line. Empty lines and lines starting with # are ignored.
+func LoadPasswordDictionary(path string) (map[string]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open password dictionary: %v", err)
+	}
+	defer f.Close()
+
+	dictionary := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dictionary[strings.ToLower(line)] = struct{}{}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read password dictionary: %v", err)
+	}
+	return dictionary, nil
+}
@@ -30,29 +30,28 @@ func SQLIdentifierPassword(i interface{}, k string) (warnings []string, errors [
 		return
 	}
 
-	if len(v) < 8 {
-		errors = append(errors, fmt.Errorf("length should equal to or greater than %d, got %d", 8, len(v)))
+	errors = DefaultPasswordPolicy().Validate(v, k, "")
+	return
+}
+
+// SQLPasswordLength only checks the hard limits of SQL Server passwords; complexity is checked against the provider
+// password policy at plan time.
+func SQLPasswordLength(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
 		return
 	}
 
+	if len(v) < 1 {
+		errors = append(errors, fmt.Errorf("%q cannot be empty", k))
+	}
+
 	if len(v) > 128 {
 		errors = append(errors, fmt.Errorf("length should be equal to or less than %d, got %d", 128, len(v)))
-		return
 	}
 
-	switch {
-	case regexp.MustCompile(`^.*[a-z]+.*$`).MatchString(v) && regexp.MustCompile(`^.*[A-Z]+.*$`).MatchString(v) && regexp.MustCompile(`^.*[0-9]+.*$`).MatchString(v):
-		return
-	case regexp.MustCompile(`^.*[a-z]+.*$`).MatchString(v) && regexp.MustCompile(`^.*[A-Z]+.*$`).MatchString(v) && regexp.MustCompile(`^.*[\W]+.*$`).MatchString(v):
-		return
-	case regexp.MustCompile(`^.*[a-z]+.*$`).MatchString(v) && regexp.MustCompile(`^.*[\W]+.*$`).MatchString(v) && regexp.MustCompile(`^.*[0-9]+.*$`).MatchString(v):
-		return
-	case regexp.MustCompile(`^.*[A-Z]+.*$`).MatchString(v) && regexp.MustCompile(`^.*[\W]+.*$`).MatchString(v) && regexp.MustCompile(`^.*[0-9]+.*$`).MatchString(v):
-		return
-	default:
-		errors = append(errors, fmt.Errorf("%q must contain characters from three of the categories - uppercase letters, lowercase letters, numbers and non-alphanumeric characters", k))
-		return
-	}
+	return
 }
 
 func SQLIdentifierPermission(i interface{}, k string) (warnings []string, errors []error) {
@@ -64,3 +63,13 @@ func SQLIdentifierPermission(i interface{}, k string) (warnings []string, errors
 
 	return
 }
+
+func SQLServerPermission(i interface{}, k string) (warnings []string, errors []error) {
+	v := i.(string)
+	if !regexp.MustCompile(`^[A-Z]+(?: [A-Z]+)*(?: ON (?:LOGIN|SERVER ROLE|ENDPOINT)::.{1,128})?$`).MatchString(v) {
+		errors = append(errors, fmt.Errorf(
+			"invalid server permission. Use uppercase letters only with a single space between words (e.g. VIEW SERVER STATE), optionally followed by ON LOGIN::name, ON SERVER ROLE::name or ON ENDPOINT::name. Got %q.", v))
+	}
+
+	return
+}
@@ -0,0 +1,95 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+)
+
+func (c *Connector) GetApplicationRole(ctx context.Context, database, roleName string) (*model.ApplicationRole, error) {
+	cmd := `SELECT principal_id, name, COALESCE(default_schema_name, '')
+			FROM [sys].[database_principals]
+			WHERE [type] = 'A' AND [name] = @roleName`
+	var role model.ApplicationRole
+	err := c.
+		setDatabase(&database).
+		QueryRowContext(ctx, cmd,
+			func(r *sql.Row) error {
+				return r.Scan(&role.PrincipalID, &role.RoleName, &role.DefaultSchema)
+			},
+			sql.Named("roleName", roleName),
+		)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (c *Connector) CreateApplicationRole(ctx context.Context, database, roleName, password, defaultSchema string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			SET @sql = 'CREATE APPLICATION ROLE ' + QuoteName(@roleName) + ' WITH PASSWORD = ' + QuoteName(@password, '''')
+			IF @defaultSchema != ''
+				BEGIN
+					SET @sql = @sql + ', DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)
+				END
+			EXEC (@sql)`
+
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+			sql.Named("password", password),
+			sql.Named("defaultSchema", defaultSchema),
+		)
+}
+
+// UpdateApplicationRole alters the name, password and default schema of the application role with a single ALTER
+// APPLICATION ROLE statement. Empty values are left unchanged.
+func (c *Connector) UpdateApplicationRole(ctx context.Context, database, roleName, newRoleName, password, defaultSchema string) error {
+	cmd := `DECLARE @options nvarchar(max) = ''
+			IF @newRoleName != '' AND @newRoleName != @roleName
+				BEGIN
+					SET @options = @options + ', NAME = ' + QuoteName(@newRoleName)
+				END
+			IF @password != ''
+				BEGIN
+					SET @options = @options + ', PASSWORD = ' + QuoteName(@password, '''')
+				END
+			IF @defaultSchema != ''
+				BEGIN
+					SET @options = @options + ', DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)
+				END
+			IF @options != ''
+				BEGIN
+					DECLARE @sql nvarchar(max) = 'ALTER APPLICATION ROLE ' + QuoteName(@roleName) + ' WITH ' + STUFF(@options, 1, 2, '')
+					EXEC (@sql)
+				END`
+
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+			sql.Named("newRoleName", newRoleName),
+			sql.Named("password", password),
+			sql.Named("defaultSchema", defaultSchema),
+		)
+}
+
+func (c *Connector) DeleteApplicationRole(ctx context.Context, database, roleName string) error {
+	cmd := `DECLARE @sql nvarchar(max)
+			IF EXISTS (SELECT 1 FROM [sys].[database_principals] WHERE [type] = 'A' AND [name] = @roleName)
+				BEGIN
+					SET @sql = 'DROP APPLICATION ROLE ' + QuoteName(@roleName)
+					EXEC (@sql)
+				END`
+
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd,
+			sql.Named("roleName", roleName),
+		)
+}
@@ -0,0 +1,60 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// EnableContainedDatabase enables contained database authentication on the server and sets the database to partially
+// contained, if needed. Azure SQL Database always supports contained users, so nothing is changed there.
+func (c *Connector) EnableContainedDatabase(ctx context.Context, database string) error {
+	cmd := `IF SERVERPROPERTY('EngineEdition') != 5
+				BEGIN
+					IF (SELECT CAST(value_in_use AS int) FROM [sys].[configurations] WHERE name = 'contained database authentication') = 0
+						BEGIN
+							EXEC sp_configure 'contained database authentication', 1;
+							RECONFIGURE;
+						END
+					IF (SELECT containment FROM [sys].[databases] WHERE database_id = DB_ID()) = 0
+						ALTER DATABASE CURRENT SET CONTAINMENT = PARTIAL
+				END`
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd)
+}
+
+// GetContainmentProblem returns why contained database users cannot be created in the database, or an empty string
+// if they can. Azure SQL Database always supports contained users.
+func (c *Connector) GetContainmentProblem(ctx context.Context, database string) (string, error) {
+	cmd := `SELECT CASE
+				WHEN SERVERPROPERTY('EngineEdition') = 5 THEN ''
+				WHEN (SELECT CAST(value_in_use AS int) FROM [sys].[configurations] WHERE name = 'contained database authentication') = 0
+					THEN 'contained database authentication is disabled on the server'
+				WHEN (SELECT containment FROM [sys].[databases] WHERE database_id = DB_ID()) = 0
+					THEN 'database ' + QuoteName(DB_NAME()) + ' is not partially contained'
+				ELSE ''
+			END`
+	var problem string
+	err := c.
+		setDatabase(&database).
+		QueryRowContext(ctx, cmd,
+			func(r *sql.Row) error {
+				return r.Scan(&problem)
+			},
+		)
+	if err != nil {
+		return "", err
+	}
+	return problem, nil
+}
+
+// MigrateUserToContained converts a user mapped to a SQL Server login into a contained database user with the password
+// of the login, keeping its name. The login is not disabled.
+func (c *Connector) MigrateUserToContained(ctx context.Context, database, username string) error {
+	cmd := `EXEC sp_migrate_user_to_contained @username = @username, @rename = N'keep_name', @disablelogin = N'do_not_disable_login'`
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd,
+			sql.Named("username", username),
+		)
+}
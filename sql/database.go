@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
 )
 
 func (c *Connector) GetDatabase(ctx context.Context, databaseName string) (*model.Database, error) {
@@ -73,3 +74,34 @@ func (c *Connector) DeleteDatabase(ctx context.Context, databaseName string) err
 			sql.Named("databaseName", databaseName),
 		)
 }
+
+// ListDatabases returns the online databases the connected principal can access whose name matches the LIKE pattern,
+// or all of them if pattern is empty. The system databases are only returned if includeSystem is set.
+func (c *Connector) ListDatabases(ctx context.Context, pattern string, includeSystem bool) ([]string, error) {
+	cmd := `SELECT name FROM [sys].[databases]
+			WHERE (database_id > 4 OR @includeSystem = 1) AND state = 0 AND HAS_DBACCESS(name) = 1
+			AND (@pattern = '' OR name LIKE @pattern)
+			ORDER BY name`
+	databases := make([]string, 0)
+	master := "master"
+	err := c.
+		setDatabase(&master).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var name string
+					if err := r.Scan(&name); err != nil {
+						return err
+					}
+					databases = append(databases, name)
+				}
+				return nil
+			},
+			sql.Named("pattern", pattern),
+			sql.Named("includeSystem", includeSystem),
+		)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list databases")
+	}
+	return databases, nil
+}
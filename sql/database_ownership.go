@@ -0,0 +1,94 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
+)
+
+// GetOwnedObjects returns the roles, schemas and schema-scoped or database-scoped securables explicitly owned by the
+// database principal.
+func (c *Connector) GetOwnedObjects(ctx context.Context, database, principalName string) ([]model.OwnedObject, error) {
+	cmd := `DECLARE @principal_id int = DATABASE_PRINCIPAL_ID(@principalName)
+			SELECT 'ROLE', '', name FROM [sys].[database_principals] WHERE type = 'R' AND owning_principal_id = @principal_id AND principal_id != @principal_id
+			UNION ALL
+			SELECT 'SCHEMA', '', name FROM [sys].[schemas] WHERE principal_id = @principal_id
+			UNION ALL
+			SELECT 'OBJECT', SCHEMA_NAME(schema_id), name FROM [sys].[objects] WHERE principal_id = @principal_id AND parent_object_id = 0
+			UNION ALL
+			SELECT 'TYPE', SCHEMA_NAME(schema_id), name FROM [sys].[types] WHERE principal_id = @principal_id AND is_user_defined = 1
+			UNION ALL
+			SELECT 'XML SCHEMA COLLECTION', SCHEMA_NAME(schema_id), name FROM [sys].[xml_schema_collections] WHERE principal_id = @principal_id
+			UNION ALL
+			SELECT 'ASSEMBLY', '', name FROM [sys].[assemblies] WHERE principal_id = @principal_id AND is_user_defined = 1
+			UNION ALL
+			SELECT 'CERTIFICATE', '', name FROM [sys].[certificates] WHERE principal_id = @principal_id
+			UNION ALL
+			SELECT 'ASYMMETRIC KEY', '', name FROM [sys].[asymmetric_keys] WHERE principal_id = @principal_id
+			UNION ALL
+			SELECT 'SYMMETRIC KEY', '', name FROM [sys].[symmetric_keys] WHERE principal_id = @principal_id AND symmetric_key_id > 256`
+	return c.queryOwnedObjects(ctx, database, cmd, sql.Named("principalName", principalName))
+}
+
+// GetSchemaObjects returns the objects, types and XML schema collections contained in the schema.
+func (c *Connector) GetSchemaObjects(ctx context.Context, database, schemaName string) ([]model.OwnedObject, error) {
+	cmd := `DECLARE @schema_id int = SCHEMA_ID(@schemaName)
+			SELECT 'OBJECT', @schemaName, name FROM [sys].[objects] WHERE schema_id = @schema_id AND parent_object_id = 0
+			UNION ALL
+			SELECT 'TYPE', @schemaName, name FROM [sys].[types] WHERE schema_id = @schema_id AND is_user_defined = 1
+			UNION ALL
+			SELECT 'XML SCHEMA COLLECTION', @schemaName, name FROM [sys].[xml_schema_collections] WHERE schema_id = @schema_id`
+	return c.queryOwnedObjects(ctx, database, cmd, sql.Named("schemaName", schemaName))
+}
+
+func (c *Connector) queryOwnedObjects(ctx context.Context, database, cmd string, args ...interface{}) ([]model.OwnedObject, error) {
+	objects := make([]model.OwnedObject, 0)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var object model.OwnedObject
+					if err := r.Scan(&object.Class, &object.Schema, &object.Name); err != nil {
+						return err
+					}
+					objects = append(objects, object)
+				}
+				return nil
+			},
+			args...,
+		)
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// TransferOwnership transfers every securable owned by the database principal to newOwner.
+func (c *Connector) TransferOwnership(ctx context.Context, database, principalName, newOwner string) error {
+	objects, err := c.GetOwnedObjects(ctx, database, principalName)
+	if err != nil {
+		return err
+	}
+	cmd := `DECLARE @stmt nvarchar(max)
+			DECLARE @securable nvarchar(max) = QuoteName(@name)
+			IF @schema != '' SET @securable = QuoteName(@schema) + '.' + @securable
+			SET @stmt = 'ALTER AUTHORIZATION ON ' + @class + '::' + @securable + ' TO ' + QuoteName(@newOwner)
+			EXEC (@stmt)`
+	for _, object := range objects {
+		err = c.
+			setDatabase(&database).
+			ExecContext(ctx, cmd,
+				sql.Named("class", object.Class),
+				sql.Named("schema", object.Schema),
+				sql.Named("name", object.Name),
+				sql.Named("newOwner", newOwner),
+			)
+		if err != nil {
+			return errors.Wrapf(err, "unable to transfer ownership of %s to [%s]", object, newOwner)
+		}
+	}
+	return nil
+}
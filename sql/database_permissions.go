@@ -3,127 +3,129 @@ package sql
 import (
 	"context"
 	"database/sql"
-	"strings"
+	"fmt"
 
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
 )
 
+// databasePermissionStatements maps the change types of UpdateDatabasePermissions to the statement verb, the
+// preposition before the principal and the statement suffix.
+var databasePermissionStatements = map[string][3]string{
+	"GRANT":                   {"GRANT", "TO", ""},
+	"GRANT WITH GRANT OPTION": {"GRANT", "TO", " WITH GRANT OPTION"},
+	"DENY":                    {"DENY", "TO", ""},
+	"REVOKE":                  {"REVOKE", "FROM", ""},
+	"REVOKE GRANT OPTION FOR": {"REVOKE GRANT OPTION FOR", "FROM", ""},
+}
+
+// GetDatabasePermissions returns the database-level permissions of a database principal by state: granted, granted
+// WITH GRANT OPTION and denied. The CONNECT permission granted to every user on creation is left out of the granted
+// permissions and reported in ConnectGranted instead. It returns nil if the principal does not exist.
 func (c *Connector) GetDatabasePermissions(ctx context.Context, database string, username string) (*model.DatabasePermissions, error) {
-	cmd := `SELECT DISTINCT pr.principal_id, pr.name, pe.permission_name
-			FROM [sys].[database_principals] AS pr LEFT JOIN [sys].[database_permissions] AS pe
-			ON pe.grantee_principal_id = pr.principal_id
-			WHERE pr.name = @username AND pe.[state] = 'G'`
-	var permissions []string
-	permsModel := model.DatabasePermissions{
-		UserName:     username,
-		DatabaseName: database,
-		Permissions:  make([]string, 0),
-	}
+	cmd := `SELECT pr.principal_id, COALESCE(pe.permission_name, ''), COALESCE(pe.[state], '')
+			FROM [sys].[database_principals] AS pr
+			LEFT JOIN [sys].[database_permissions] AS pe ON pe.grantee_principal_id = pr.principal_id AND pe.class = 0
+			WHERE pr.name = @username
+			ORDER BY pe.permission_name`
+	var permissions *model.DatabasePermissions
 	err := c.
 		setDatabase(&database).
 		QueryContext(ctx, cmd,
 			func(r *sql.Rows) error {
 				for r.Next() {
-					var name, permission_name string
-					var principal_id string
-					if err := r.Scan(&principal_id, &name, &permission_name); err != nil {
-						// Check for a scan error.
-						// Query rows will be closed with defer.
+					var principalId int
+					var permission, state string
+					if err := r.Scan(&principalId, &permission, &state); err != nil {
 						return err
 					}
-					if permission_name == "CONNECT" {
-						continue
+					if permissions == nil {
+						permissions = &model.DatabasePermissions{
+							UserName:                   username,
+							DatabaseName:               database,
+							PrincipalID:                principalId,
+							Permissions:                make([]string, 0),
+							WithGrantOptionPermissions: make([]string, 0),
+							DenyPermissions:            make([]string, 0),
+						}
+					}
+					switch state {
+					case "G":
+						if permission == "CONNECT" {
+							permissions.ConnectGranted = true
+						} else {
+							permissions.Permissions = append(permissions.Permissions, permission)
+						}
+					case "W":
+						permissions.WithGrantOptionPermissions = append(permissions.WithGrantOptionPermissions, permission)
+					case "D":
+						permissions.DenyPermissions = append(permissions.DenyPermissions, permission)
 					}
-					permissions = append(permissions, permission_name)
 				}
 				return nil
 			},
 			sql.Named("username", username),
 		)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, err
 	}
-
-	if len(permissions) == 0 {
-		permsModel.Permissions = make([]string, 0)
-	} else {
-		permsModel.Permissions = permissions
-	}
-	return &permsModel, nil
+	return permissions, nil
 }
 
 func (c *Connector) CreateDatabasePermissions(ctx context.Context, permissions *model.DatabasePermissions) error {
-	cmd := `DECLARE @stmt nvarchar(max)
-			DECLARE perm_cur CURSOR FOR SELECT value FROM String_Split(@permissions, ',')
-			DECLARE @permission_name nvarchar(max)
-			OPEN perm_cur
-			FETCH NEXT FROM perm_cur INTO @permission_name
-			WHILE @@FETCH_STATUS = 0
-				BEGIN
-					SET @stmt = 'GRANT ' + @permission_name + ' TO ' + QuoteName(@username)
-					EXEC (@stmt)
-					FETCH NEXT FROM perm_cur INTO @permission_name
-				END
-			CLOSE perm_cur
-			DEALLOCATE perm_cur
-			`
-	return c.
-		setDatabase(&permissions.DatabaseName).
-		ExecContext(ctx, cmd,
-			sql.Named("username", permissions.UserName),
-			sql.Named("permissions", strings.Join(permissions.Permissions, ",")),
-		)
+	for _, change := range []struct {
+		permissions []string
+		changeType  string
+	}{
+		{permissions.Permissions, "GRANT"},
+		{permissions.WithGrantOptionPermissions, "GRANT WITH GRANT OPTION"},
+		{permissions.DenyPermissions, "DENY"},
+	} {
+		if err := c.UpdateDatabasePermissions(ctx, permissions.DatabaseName, permissions.UserName, change.permissions, change.changeType); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
+// UpdateDatabasePermissions applies changeType (GRANT, GRANT WITH GRANT OPTION, DENY, REVOKE or REVOKE GRANT OPTION FOR)
+// for every permission to the user, one statement per permission. DENY and REVOKE of a permission the user holds WITH
+// GRANT OPTION cascade to the principals it was granted to by the user.
 func (c *Connector) UpdateDatabasePermissions(ctx context.Context, database string, username string, permissions []string, changeType string) error {
 	cmd := `DECLARE @stmt nvarchar(max)
-			DECLARE @applies nvarchar(10)
-			IF @changeType = 'GRANT' SET @applies = 'TO'
-			IF @changeType = 'REVOKE' SET @applies = 'FROM'
-			DECLARE perm_cur CURSOR FOR SELECT value FROM String_Split(@permissions, ',')
-			DECLARE @permission_name nvarchar(max)
-			OPEN perm_cur
-			FETCH NEXT FROM perm_cur INTO @permission_name
-			WHILE @@FETCH_STATUS = 0
+			SET @stmt = @verb + ' ' + @permission + ' ' + @applies + ' ' + QuoteName(@username) + @suffix
+			IF @verb != 'GRANT' AND EXISTS (SELECT 1 FROM [sys].[database_permissions]
+				WHERE class = 0 AND grantee_principal_id = DATABASE_PRINCIPAL_ID(@username) AND permission_name = @permission AND [state] = 'W')
 				BEGIN
-					SET @stmt = @changeType + ' ' + @permission_name + ' ' + @applies + ' ' + QuoteName(@username)
-					EXEC (@stmt)
-					FETCH NEXT FROM perm_cur INTO @permission_name
+					SET @stmt = @stmt + ' CASCADE'
 				END
-			CLOSE perm_cur
-			DEALLOCATE perm_cur
-			`
-	return c.
-		setDatabase(&database).
-		ExecContext(ctx, cmd,
-			sql.Named("username", username),
-			sql.Named("permissions", strings.Join(permissions, ",")),
-			sql.Named("changeType", changeType),
-		)
+			EXEC (@stmt)`
+	statement, ok := databasePermissionStatements[changeType]
+	if !ok {
+		return fmt.Errorf("invalid change type %q", changeType)
+	}
+	for _, permission := range permissions {
+		err := c.
+			setDatabase(&database).
+			ExecContext(ctx, cmd,
+				sql.Named("username", username),
+				sql.Named("permission", permission),
+				sql.Named("verb", statement[0]),
+				sql.Named("applies", statement[1]),
+				sql.Named("suffix", statement[2]),
+			)
+		if err != nil {
+			return errors.Wrapf(err, "unable to %s [%s]", changeType, permission)
+		}
+	}
+	return nil
 }
 
+// DeleteDatabasePermissions revokes the granted and denied permissions of the user.
 func (c *Connector) DeleteDatabasePermissions(ctx context.Context, permissions *model.DatabasePermissions) error {
-	cmd := `DECLARE @stmt nvarchar(max)
-			DECLARE perm_cur CURSOR FOR SELECT value FROM String_Split(@permissions, ',')
-			DECLARE @permission_name nvarchar(max)
-			OPEN perm_cur
-			FETCH NEXT FROM perm_cur INTO @permission_name
-			WHILE @@FETCH_STATUS = 0
-				BEGIN
-					SET @stmt = 'REVOKE ' + @permission_name + ' FROM ' + QuoteName(@username)
-					EXEC (@stmt)
-					FETCH NEXT FROM perm_cur INTO @permission_name
-				END
-			CLOSE perm_cur
-			DEALLOCATE perm_cur
-			`
-	return c.
-		setDatabase(&permissions.DatabaseName).
-		ExecContext(ctx, cmd,
-			sql.Named("username", permissions.UserName),
-			sql.Named("permissions", strings.Join(permissions.Permissions, ",")),
-		)
+	var all []string
+	all = append(all, permissions.Permissions...)
+	all = append(all, permissions.WithGrantOptionPermissions...)
+	all = append(all, permissions.DenyPermissions...)
+	return c.UpdateDatabasePermissions(ctx, permissions.DatabaseName, permissions.UserName, all, "REVOKE")
 }
@@ -0,0 +1,144 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
+)
+
+// GetDatabasePrincipals returns the principals of the database whose name matches the LIKE pattern namePattern and
+// that are direct or nested members of the database role roleName. Empty filters match every principal. Like GetUser,
+// Roles contains the nested role memberships and LoginName the server principal with the same SID.
+func (c *Connector) GetDatabasePrincipals(ctx context.Context, database, namePattern, roleName string) ([]model.DatabasePrincipal, error) {
+	logins, err := c.getServerPrincipalNames(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := `SELECT p.principal_id, p.name, p.type_desc, COALESCE(p.authentication_type_desc, ''), COALESCE(CONVERT(VARCHAR(85), p.[sid], 1), ''),
+				COALESCE(p.default_schema_name, ''), COALESCE(p.default_language_name, ''), p.create_date, p.modify_date
+			FROM [sys].[database_principals] p
+			WHERE (@namePattern = '' OR p.name LIKE @namePattern)
+			ORDER BY p.name`
+	var principals []model.DatabasePrincipal
+	err = c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var principal model.DatabasePrincipal
+					err := r.Scan(&principal.PrincipalID, &principal.Name, &principal.TypeDesc, &principal.AuthType, &principal.SIDStr,
+						&principal.DefaultSchema, &principal.DefaultLanguage, &principal.CreateDate, &principal.ModifyDate)
+					if err != nil {
+						return err
+					}
+					principals = append(principals, principal)
+				}
+				return nil
+			},
+			sql.Named("namePattern", namePattern),
+		)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read principals of database [%s]", database)
+	}
+
+	cmd = `SELECT drm.member_principal_id, role.principal_id, role.name
+			FROM [sys].[database_role_members] drm
+			INNER JOIN [sys].[database_principals] role ON drm.role_principal_id = role.principal_id`
+	memberships := make(map[int64][]int64)
+	roleNames := make(map[int64]string)
+	err = c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var memberId, roleId int64
+					var role string
+					if err := r.Scan(&memberId, &roleId, &role); err != nil {
+						return err
+					}
+					memberships[memberId] = append(memberships[memberId], roleId)
+					roleNames[roleId] = role
+				}
+				return nil
+			},
+		)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read role memberships of database [%s]", database)
+	}
+
+	cmd = `SELECT principal_id, name FROM [sys].[schemas] ORDER BY name`
+	schemas := make(map[int64][]string)
+	err = c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var ownerId int64
+					var schema string
+					if err := r.Scan(&ownerId, &schema); err != nil {
+						return err
+					}
+					schemas[ownerId] = append(schemas[ownerId], schema)
+				}
+				return nil
+			},
+		)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read schemas of database [%s]", database)
+	}
+
+	result := make([]model.DatabasePrincipal, 0, len(principals))
+	for _, principal := range principals {
+		principal.Roles = nestedRoles(principal.PrincipalID, memberships, roleNames)
+		if roleName != "" && !containsFold(principal.Roles, roleName) {
+			continue
+		}
+		principal.OwnedSchemas = schemas[principal.PrincipalID]
+		if principal.AuthType == "INSTANCE" || principal.AuthType == "EXTERNAL" {
+			principal.LoginName = logins[principal.SIDStr]
+			if principal.LoginName == "" && strings.HasSuffix(principal.SIDStr, "AADE") && len(principal.SIDStr) > 34 {
+				principal.LoginName = logins[principal.SIDStr[:34]]
+			}
+		}
+		result = append(result, principal)
+	}
+	return result, nil
+}
+
+// nestedRoles returns the names of the roles principalId is a direct or nested member of, sorted by name.
+func nestedRoles(principalId int64, memberships map[int64][]int64, roleNames map[int64]string) []string {
+	seen := make(map[int64]struct{})
+	queue := []int64{principalId}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, roleId := range memberships[id] {
+			if _, ok := seen[roleId]; ok {
+				continue
+			}
+			seen[roleId] = struct{}{}
+			queue = append(queue, roleId)
+		}
+	}
+	roles := make([]string, 0, len(seen))
+	for roleId := range seen {
+		roles = append(roles, roleNames[roleId])
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// containsFold reports whether values contains value, ignoring case like the default collation of SQL Server.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNestedRoles(t *testing.T) {
+	// user 5 is a member of role 10, which is a member of roles 11 and 12; role 12 is a member of role 10.
+	memberships := map[int64][]int64{
+		5:  {10},
+		10: {11, 12},
+		12: {10},
+	}
+	roleNames := map[int64]string{10: "app_reader", 11: "db_datareader", 12: "app_base"}
+
+	if roles := nestedRoles(5, memberships, roleNames); !reflect.DeepEqual(roles, []string{"app_base", "app_reader", "db_datareader"}) {
+		t.Errorf("unexpected roles of user: %v", roles)
+	}
+	if roles := nestedRoles(11, memberships, roleNames); len(roles) != 0 {
+		t.Errorf("expected no roles, got %v", roles)
+	}
+}
+
+func TestContainsFold(t *testing.T) {
+	roles := []string{"db_datareader", "App_Reader"}
+	if !containsFold(roles, "DB_DataReader") || !containsFold(roles, "app_reader") {
+		t.Errorf("expected roles to match case-insensitively")
+	}
+	if containsFold(roles, "db_datawriter") {
+		t.Errorf("unexpected match of db_datawriter")
+	}
+}
@@ -109,3 +109,36 @@ func (c *Connector) UpdateDatabaseRoleOwner(ctx context.Context, database string
 			sql.Named("roleName", roleName),
 		)
 }
+
+// GetDatabaseRolePermissions returns the permissions granted to the role on the database, on schemas and on objects.
+// Denied permissions and permissions on columns are left out.
+func (c *Connector) GetDatabaseRolePermissions(ctx context.Context, database, roleName string) ([]model.RolePermission, error) {
+	cmd := `SELECT pe.permission_name,
+				CASE pe.class WHEN 0 THEN 'DATABASE' WHEN 3 THEN 'SCHEMA' ELSE 'OBJECT' END,
+				CASE pe.class WHEN 1 THEN COALESCE(OBJECT_SCHEMA_NAME(pe.major_id), '') ELSE '' END,
+				CASE pe.class WHEN 1 THEN COALESCE(OBJECT_NAME(pe.major_id), '') WHEN 3 THEN COALESCE(SCHEMA_NAME(pe.major_id), '') ELSE '' END
+			FROM [sys].[database_permissions] pe
+			WHERE pe.grantee_principal_id = DATABASE_PRINCIPAL_ID(@roleName)
+				AND pe.class IN (0, 1, 3) AND pe.minor_id = 0 AND pe.[state] IN ('G', 'W')
+			ORDER BY pe.class, 3, 4, pe.permission_name`
+	permissions := make([]model.RolePermission, 0)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var permission model.RolePermission
+					if err := r.Scan(&permission.Permission, &permission.Securable.Type, &permission.Securable.Schema, &permission.Securable.Name); err != nil {
+						return err
+					}
+					permissions = append(permissions, permission)
+				}
+				return nil
+			},
+			sql.Named("roleName", roleName),
+		)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
@@ -0,0 +1,118 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
+)
+
+// GetDatabaseRoleMember returns the members of the database role, or nil if the role does not exist.
+// If managedMembers is non-empty: returns only members that are both in the role and in managedMembers.
+// If managedMembers is nil or empty: returns all members in the role.
+func (c *Connector) GetDatabaseRoleMember(ctx context.Context, database, roleName string, managedMembers []string) (*model.DatabaseRoleMember, error) {
+	cmd := `SELECT member.name
+			FROM [sys].[database_principals] role
+			LEFT JOIN [sys].[database_role_members] drm ON drm.role_principal_id = role.principal_id
+			LEFT JOIN [sys].[database_principals] member ON drm.member_principal_id = member.principal_id
+			WHERE role.type = 'R' AND role.name = @roleName`
+	var (
+		exists bool
+		inRole []string
+	)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var name sql.NullString
+					if err := r.Scan(&name); err != nil {
+						return err
+					}
+					exists = true
+					if name.Valid {
+						inRole = append(inRole, name.String)
+					}
+				}
+				return nil
+			},
+			sql.Named("roleName", roleName),
+		)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	members := make([]string, 0, len(inRole))
+	if len(managedMembers) == 0 {
+		members = append(members, inRole...)
+		return &model.DatabaseRoleMember{RoleName: roleName, Members: members}, nil
+	}
+	managedSet := make(map[string]struct{}, len(managedMembers))
+	for _, m := range managedMembers {
+		managedSet[m] = struct{}{}
+	}
+	for _, name := range inRole {
+		if _, ok := managedSet[name]; ok {
+			members = append(members, name)
+		}
+	}
+	return &model.DatabaseRoleMember{RoleName: roleName, Members: members}, nil
+}
+
+// UpdateDatabaseRoleMember adds (changeType ADD) or drops (changeType DROP) the members of the database role.
+func (c *Connector) UpdateDatabaseRoleMember(ctx context.Context, database, roleName string, members []string, changeType string) error {
+	cmd := `DECLARE @stmt nvarchar(max)
+			SET @stmt = 'ALTER ROLE ' + QuoteName(@roleName) + ' ' + @changeType + ' MEMBER ' + QuoteName(@member)
+			EXEC (@stmt)`
+	switch changeType {
+	case "ADD", "DROP":
+	default:
+		return fmt.Errorf("invalid change type %q", changeType)
+	}
+	for _, member := range members {
+		err := c.
+			setDatabase(&database).
+			ExecContext(ctx, cmd,
+				sql.Named("roleName", roleName),
+				sql.Named("member", member),
+				sql.Named("changeType", changeType),
+			)
+		if err != nil {
+			return errors.Wrapf(err, "unable to %s member [%s]", changeType, member)
+		}
+	}
+	return nil
+}
+
+// GetDatabaseRoleMemberships returns the direct memberships of every database role of the database.
+func (c *Connector) GetDatabaseRoleMemberships(ctx context.Context, database string) ([]model.RoleMembership, error) {
+	cmd := `SELECT role.name, member.name, member.type_desc
+			FROM [sys].[database_role_members] drm
+			INNER JOIN [sys].[database_principals] role ON drm.role_principal_id = role.principal_id
+			INNER JOIN [sys].[database_principals] member ON drm.member_principal_id = member.principal_id
+			ORDER BY role.name, member.name`
+	memberships := make([]model.RoleMembership, 0)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var membership model.RoleMembership
+					if err := r.Scan(&membership.RoleName, &membership.MemberName, &membership.MemberType); err != nil {
+						return err
+					}
+					memberships = append(memberships, membership)
+				}
+				return nil
+			},
+		)
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
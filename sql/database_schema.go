@@ -63,22 +63,24 @@ func (c *Connector) CreateDatabaseSchema(ctx context.Context, database, schemaNa
 		)
 }
 
-func (c *Connector) DeleteDatabaseSchema(ctx context.Context, database, schemaName string) error {
+// DeleteDatabaseSchema drops the schema after transferring it to newOwner, or to the connected user if newOwner is empty.
+func (c *Connector) DeleteDatabaseSchema(ctx context.Context, database, schemaName, newOwner string) error {
 	cmd := `DECLARE @stmt nvarchar(max)
 			DECLARE @sql NVARCHAR(max)
 			DECLARE @user_name NVARCHAR(max) = (SELECT USER_NAME())
+			IF @newOwner != '' SET @user_name = @newOwner
 			IF @@VERSION LIKE 'Microsoft SQL Azure%' AND @database = 'master'
 				BEGIN
 					SET @stmt = 'IF EXISTS (SELECT 1 FROM [sys].[schemas] WHERE [name] = ' + QuoteName(@schemaName, '''') + ') ' +
-								'DROP SCHEMA ' + @schemaName
+								'DROP SCHEMA ' + QuoteName(@schemaName)
 				END
 			ELSE
 				BEGIN
 					SET @sql =  'IF EXISTS (SELECT 1 FROM [sys].[schemas] dp1 INNER JOIN [sys].[database_principals] dp2 ON dp1.principal_id = dp2.principal_id AND dp1.name = ' + QuoteName(@schemaName, '''') + ') ' +
-								'ALTER AUTHORIZATION ON SCHEMA:: [' + @schemaName + '] TO [' + @user_name + ']'
+								'ALTER AUTHORIZATION ON SCHEMA::' + QuoteName(@schemaName) + ' TO ' + QuoteName(@user_name)
 					EXEC sp_executesql @sql;
 					SET @stmt = 'IF EXISTS (SELECT 1 FROM [sys].[schemas] WHERE [name] = ' + QuoteName(@schemaName, '''') + ') ' +
-								'DROP SCHEMA ' + @schemaName
+								'DROP SCHEMA ' + QuoteName(@schemaName)
 				END
 			EXEC (@stmt)`
 
@@ -87,6 +89,7 @@ func (c *Connector) DeleteDatabaseSchema(ctx context.Context, database, schemaNa
 		ExecContext(ctx, cmd,
 			sql.Named("database", database),
 			sql.Named("schemaName", schemaName),
+			sql.Named("newOwner", newOwner),
 		)
 }
 
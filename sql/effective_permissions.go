@@ -0,0 +1,63 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+)
+
+// GetEffectivePermissions returns the permissions sys.fn_my_permissions reports on a securable while impersonating a
+// database user, or a login when isLogin is set. Permissions inherited from roles and Windows or Entra ID groups are
+// included; server roles and server-level grants only for a login, as EXECUTE AS USER is scoped to the database. An
+// empty name targets the current database for the DATABASE class. The
+// impersonation is reverted if reading the permissions fails, so that the pooled connection is not left impersonating.
+func (c *Connector) GetEffectivePermissions(ctx context.Context, database, principalName string, isLogin bool, securableClass, securableSchema, securableName string) ([]model.EffectivePermission, error) {
+	cmd := `DECLARE @securable nvarchar(max) = CASE
+				WHEN @securableName = '' THEN NULL
+				WHEN @securableSchema = '' THEN QuoteName(@securableName)
+				ELSE QuoteName(@securableSchema) + '.' + QuoteName(@securableName)
+			END
+			DECLARE @result TABLE (subentity_name nvarchar(128), permission_name nvarchar(128))
+			DECLARE @impersonating bit = 0
+			BEGIN TRY
+				IF @isLogin = 1
+					EXECUTE AS LOGIN = @principalName
+				ELSE
+					EXECUTE AS USER = @principalName
+				SET @impersonating = 1
+				INSERT INTO @result
+					SELECT COALESCE(subentity_name, ''), permission_name FROM sys.fn_my_permissions(@securable, @securableClass)
+				REVERT
+			END TRY
+			BEGIN CATCH
+				IF @impersonating = 1
+					REVERT;
+				THROW;
+			END CATCH
+			SELECT subentity_name, permission_name FROM @result ORDER BY subentity_name, permission_name`
+	permissions := make([]model.EffectivePermission, 0)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var permission model.EffectivePermission
+					if err := r.Scan(&permission.Column, &permission.Permission); err != nil {
+						return err
+					}
+					permissions = append(permissions, permission)
+				}
+				return nil
+			},
+			sql.Named("principalName", principalName),
+			sql.Named("isLogin", isLogin),
+			sql.Named("securableClass", securableClass),
+			sql.Named("securableSchema", securableSchema),
+			sql.Named("securableName", securableName),
+		)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
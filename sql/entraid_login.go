@@ -10,9 +10,9 @@ import (
 func (c *Connector) GetEntraIDLogin(ctx context.Context, name string) (*model.EntraIDLogin, error) {
 	var login model.EntraIDLogin
 	err := c.QueryRowContext(ctx,
-		"SELECT name, default_database_name, default_language_name, principal_id, CONVERT(VARCHAR(85), [sid], 1) FROM [master].[sys].[server_principals] WHERE [type] NOT IN ('G', 'R') and [name] = @name",
+		"SELECT name, default_database_name, default_language_name, principal_id, CONVERT(VARCHAR(85), [sid], 1), is_disabled, CAST(CASE WHEN [type] = 'X' THEN 1 ELSE 0 END AS bit) FROM [master].[sys].[server_principals] WHERE [type] NOT IN ('G', 'R') and [name] = @name",
 		func(r *sql.Row) error {
-			return r.Scan(&login.LoginName, &login.DefaultDatabase, &login.DefaultLanguage, &login.PrincipalID, &login.Sid)
+			return r.Scan(&login.LoginName, &login.DefaultDatabase, &login.DefaultLanguage, &login.PrincipalID, &login.Sid, &login.IsDisabled, &login.IsGroup)
 		},
 		sql.Named("name", name),
 	)
@@ -25,28 +25,90 @@ func (c *Connector) GetEntraIDLogin(ctx context.Context, name string) (*model.En
 	return &login, nil
 }
 
-func (c *Connector) CreateEntraIDLogin(ctx context.Context, name, objectId string) error {
+// CreateEntraIDLogin creates a login from Microsoft Entra ID. Azure SQL Database does not support default databases and
+// languages, so setting them fails there.
+func (c *Connector) CreateEntraIDLogin(ctx context.Context, name, objectId, defaultDatabase, defaultLanguage string, enabled bool) error {
 	cmd := `DECLARE @stmt nvarchar(max)
+			DECLARE @options nvarchar(max) = ''
 			SET @stmt = 'CREATE LOGIN ' + QuoteName(@name) + ' FROM EXTERNAL PROVIDER'
 			IF @@VERSION LIKE 'Microsoft SQL Azure%'
 				BEGIN
 					IF @objectId != ''
 						BEGIN
-							SET @stmt = @stmt + ' WITH OBJECT_ID = ' + QuoteName(@objectId, '''')
+							SET @options = @options + ', OBJECT_ID = ' + QuoteName(@objectId, '''')
 						END
 				END
+			-- DEFAULT_DATABASE and DEFAULT_LANGUAGE are not supported by Azure SQL Database (engine edition 5)
+			IF SERVERPROPERTY('EngineEdition') = 5 AND (@defaultDatabase != '' OR @defaultLanguage != '')
+				THROW 50000, 'default_database and default_language are not supported on Azure SQL Database', 1;
+			IF @defaultDatabase != ''
+				BEGIN
+					SET @options = @options + ', DEFAULT_DATABASE = ' + QuoteName(@defaultDatabase)
+				END
+			IF @defaultLanguage != ''
+				BEGIN
+					SET @options = @options + ', DEFAULT_LANGUAGE = ' + QuoteName(@defaultLanguage)
+				END
+			IF @options != ''
+				BEGIN
+					SET @stmt = @stmt + ' WITH ' + STUFF(@options, 1, 2, '')
+				END
+			IF @enabled = 0
+				BEGIN
+					SET @stmt = @stmt + '; ALTER LOGIN ' + QuoteName(@name) + ' DISABLE'
+				END
 			EXEC (@stmt)`
 	return c.
 		ExecContext(ctx, cmd,
 			sql.Named("name", name),
 			sql.Named("objectId", objectId),
+			sql.Named("defaultDatabase", defaultDatabase),
+			sql.Named("defaultLanguage", defaultLanguage),
+			sql.Named("enabled", enabled),
 		)
 }
 
-func (c *Connector) DeleteEntraIDLogin(ctx context.Context, name string) error {
-	// Try to kill sessions but continue even if it fails
-	_ = c.killSessionsForLogin(ctx, name)
+// UpdateEntraIDLogin alters the default database and language of the login, when not empty, and enables or disables
+// it. Azure SQL Database does not support default databases and languages, so setting them fails there.
+func (c *Connector) UpdateEntraIDLogin(ctx context.Context, name, defaultDatabase, defaultLanguage string, enabled bool) error {
+	cmd := `DECLARE @stmt nvarchar(max) = ''
+			DECLARE @options nvarchar(max) = ''
+			IF SERVERPROPERTY('EngineEdition') = 5 AND (@defaultDatabase != '' OR @defaultLanguage != '')
+				THROW 50000, 'default_database and default_language are not supported on Azure SQL Database', 1;
+			IF @defaultDatabase != '' AND NOT @defaultDatabase IN (SELECT default_database_name FROM [master].[sys].[server_principals] WHERE [name] = @name)
+				BEGIN
+					SET @options = @options + ', DEFAULT_DATABASE = ' + QuoteName(@defaultDatabase)
+				END
+			IF @defaultLanguage != '' AND NOT @defaultLanguage IN (SELECT default_language_name FROM [master].[sys].[server_principals] WHERE [name] = @name)
+				BEGIN
+					SET @options = @options + ', DEFAULT_LANGUAGE = ' + QuoteName(@defaultLanguage)
+				END
+			IF @options != ''
+				BEGIN
+					SET @stmt = 'ALTER LOGIN ' + QuoteName(@name) + ' WITH ' + STUFF(@options, 1, 2, '') + '; '
+				END
+			IF @enabled = 1 AND EXISTS (SELECT 1 FROM [master].[sys].[server_principals] WHERE [name] = @name AND is_disabled = 1)
+				BEGIN
+					SET @stmt = @stmt + 'ALTER LOGIN ' + QuoteName(@name) + ' ENABLE'
+				END
+			IF @enabled = 0 AND EXISTS (SELECT 1 FROM [master].[sys].[server_principals] WHERE [name] = @name AND is_disabled = 0)
+				BEGIN
+					SET @stmt = @stmt + 'ALTER LOGIN ' + QuoteName(@name) + ' DISABLE'
+				END
+			IF @stmt != ''
+				BEGIN
+					EXEC (@stmt)
+				END`
+	return c.
+		ExecContext(ctx, cmd,
+			sql.Named("name", name),
+			sql.Named("defaultDatabase", defaultDatabase),
+			sql.Named("defaultLanguage", defaultLanguage),
+			sql.Named("enabled", enabled),
+		)
+}
 
+func (c *Connector) DeleteEntraIDLogin(ctx context.Context, name string) error {
 	cmd := `DECLARE @stmt nvarchar(max)
 			SET @stmt = 'IF EXISTS (SELECT 1 FROM [master].[sys].[server_principals] WHERE [name] = ' + QuoteName(@name, '''') + ') ' +
 						'DROP LOGIN ' + QuoteName(@name)
@@ -88,9 +88,6 @@ func (c *Connector) UpdateLogin(ctx context.Context, name, password, defaultData
 }
 
 func (c *Connector) DeleteLogin(ctx context.Context, name string) error {
-	if err := c.killSessionsForLogin(ctx, name); err != nil {
-		return err
-	}
 	cmd := `DECLARE @sql nvarchar(max)
 			SET @sql = 'IF EXISTS (SELECT 1 FROM [master].[sys].[sql_logins] WHERE [name] = ' + QuoteName(@name, '''') + ') ' +
 						'DROP LOGIN ' + QuoteName(@name)
@@ -100,25 +97,3 @@ func (c *Connector) DeleteLogin(ctx context.Context, name string) error {
 			sql.Named("name", name),
 		)
 }
-
-func (c *Connector) killSessionsForLogin(ctx context.Context, name string) error {
-	cmd := `-- adapted from https://stackoverflow.com/a/5178097/38055
-			DECLARE sessionsToKill CURSOR FAST_FORWARD FOR
-				SELECT session_id
-				FROM sys.dm_exec_sessions
-				WHERE login_name = @name
-			OPEN sessionsToKill
-			DECLARE @sessionId INT
-			DECLARE @statement NVARCHAR(200)
-			FETCH NEXT FROM sessionsToKill INTO @sessionId
-			WHILE @@FETCH_STATUS = 0
-			BEGIN
-				PRINT 'Killing session ' + CAST(@sessionId AS NVARCHAR(20)) + ' for login ' + @name
-				SET @statement = 'KILL ' + CAST(@sessionId AS NVARCHAR(20))
-				EXEC sp_executesql @statement
-				FETCH NEXT FROM sessionsToKill INTO @sessionId
-			END
-			CLOSE sessionsToKill
-			DEALLOCATE sessionsToKill`
-	return c.ExecContext(ctx, cmd, sql.Named("name", name))
-}
@@ -0,0 +1,67 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+)
+
+func (c *Connector) GetLoginSessions(ctx context.Context, name string) ([]model.LoginSession, error) {
+	cmd := `SELECT session_id, COALESCE(host_name, ''), COALESCE(program_name, ''), login_time
+			FROM [sys].[dm_exec_sessions]
+			WHERE login_name = @name AND session_id <> @@SPID
+			ORDER BY session_id`
+	var sessions []model.LoginSession
+	err := c.
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var session model.LoginSession
+					if err := r.Scan(&session.SessionID, &session.HostName, &session.ProgramName, &session.LoginTime); err != nil {
+						return err
+					}
+					sessions = append(sessions, session)
+				}
+				return nil
+			},
+			sql.Named("name", name),
+		)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (c *Connector) KillLoginSessions(ctx context.Context, name string) error {
+	cmd := `-- adapted from https://stackoverflow.com/a/5178097/38055
+			DECLARE sessionsToKill CURSOR FAST_FORWARD FOR
+				SELECT session_id
+				FROM sys.dm_exec_sessions
+				WHERE login_name = @name AND session_id <> @@SPID
+			OPEN sessionsToKill
+			DECLARE @sessionId INT
+			DECLARE @statement NVARCHAR(200)
+			FETCH NEXT FROM sessionsToKill INTO @sessionId
+			WHILE @@FETCH_STATUS = 0
+			BEGIN
+				PRINT 'Killing session ' + CAST(@sessionId AS NVARCHAR(20)) + ' for login ' + @name
+				SET @statement = 'KILL ' + CAST(@sessionId AS NVARCHAR(20))
+				EXEC sp_executesql @statement
+				FETCH NEXT FROM sessionsToKill INTO @sessionId
+			END
+			CLOSE sessionsToKill
+			DEALLOCATE sessionsToKill`
+	return c.ExecContext(ctx, cmd, sql.Named("name", name))
+}
+
+func (c *Connector) DisableLogin(ctx context.Context, name string) error {
+	cmd := `DECLARE @stmt nvarchar(max)
+			SET @stmt = 'IF EXISTS (SELECT 1 FROM [master].[sys].[server_principals] WHERE [name] = ' + QuoteName(@name, '''') + ') ' +
+						'ALTER LOGIN ' + QuoteName(@name) + ' DISABLE'
+			EXEC (@stmt)`
+	return c.
+		ExecContext(ctx, cmd,
+			sql.Named("name", name),
+		)
+}
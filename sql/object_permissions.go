@@ -0,0 +1,240 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
+)
+
+// securableClasses maps the securable types to their class in [sys].[database_permissions].
+var securableClasses = map[string]int{
+	"OBJECT":                     1,
+	"SCHEMA":                     3,
+	"ASSEMBLY":                   5,
+	"TYPE":                       6,
+	"XML SCHEMA COLLECTION":      10,
+	"CERTIFICATE":                25,
+	"DATABASE SCOPED CREDENTIAL": 33,
+}
+
+// securableMajorIdCmd resolves the major_id of the securable identified by @type, @schema and @name into @major_id,
+// and its quoted name into @securable.
+const securableMajorIdCmd = `DECLARE @securable nvarchar(max) = QuoteName(@name)
+			IF @schema != '' SET @securable = QuoteName(@schema) + '.' + @securable
+			DECLARE @major_id int
+			IF @type = 'OBJECT' SET @major_id = OBJECT_ID(@securable)
+			IF @type = 'SCHEMA' SET @major_id = SCHEMA_ID(@name)
+			IF @type = 'TYPE' SET @major_id = TYPE_ID(@securable)
+			IF @type = 'ASSEMBLY' SELECT @major_id = assembly_id FROM [sys].[assemblies] WHERE name = @name
+			IF @type = 'XML SCHEMA COLLECTION' SELECT @major_id = xml_collection_id FROM [sys].[xml_schema_collections] WHERE name = @name AND schema_id = SCHEMA_ID(@schema)
+			IF @type = 'CERTIFICATE' SELECT @major_id = certificate_id FROM [sys].[certificates] WHERE name = @name
+			IF @type = 'DATABASE SCOPED CREDENTIAL' SELECT @major_id = credential_id FROM [sys].[database_scoped_credentials] WHERE name = @name
+			`
+
+// GetObjectPermissions returns the permissions granted and denied to a database principal on a securable. For a
+// securable restricted to columns, Securable.Columns is set to the configured columns that still exist, only the
+// permissions held on all of them are returned, and the permissions held on any other column of the object are
+// returned in OtherColumnPermissions. It returns nil if the principal or the securable does not exist.
+func (c *Connector) GetObjectPermissions(ctx context.Context, database, principalName string, securable model.Securable) (*model.ObjectPermissions, error) {
+	class, ok := securableClasses[securable.Type]
+	if !ok {
+		return nil, fmt.Errorf("invalid securable type %q", securable.Type)
+	}
+	cmd := securableMajorIdCmd + `SELECT pr.principal_id, COALESCE(col.name, ''), COALESCE(pe.permission_name, ''), COALESCE(pe.[state], '')
+			FROM [sys].[database_principals] pr
+			LEFT JOIN [sys].[database_permissions] pe ON pe.grantee_principal_id = pr.principal_id
+				AND pe.class = @class AND pe.major_id = @major_id
+				AND ((pe.minor_id = 0 AND @columns = 0) OR (pe.minor_id != 0 AND @columns = 1))
+			LEFT JOIN [sys].[columns] col ON pe.class = 1 AND col.[object_id] = pe.major_id AND col.column_id = pe.minor_id
+			WHERE pr.name = @principalName AND @major_id IS NOT NULL`
+	type columnPermission struct{ column, permission string }
+	var permissions *model.ObjectPermissions
+	states := make(map[columnPermission]string)
+	columnNames := make(map[string]string)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var principalId int
+					var column, permission, state string
+					if err := r.Scan(&principalId, &column, &permission, &state); err != nil {
+						return err
+					}
+					if permissions == nil {
+						permissions = &model.ObjectPermissions{
+							DatabaseName:               database,
+							PrincipalName:              principalName,
+							PrincipalID:                principalId,
+							Securable:                  securable,
+							Permissions:                make([]string, 0),
+							WithGrantOptionPermissions: make([]string, 0),
+							DenyPermissions:            make([]string, 0),
+						}
+					}
+					if permission != "" {
+						states[columnPermission{strings.ToLower(column), permission}] = state
+						columnNames[strings.ToLower(column)] = column
+					}
+				}
+				return nil
+			},
+			sql.Named("principalName", principalName),
+			sql.Named("class", class),
+			sql.Named("columns", len(securable.Columns) > 0),
+			sql.Named("type", securable.Type),
+			sql.Named("schema", securable.Schema),
+			sql.Named("name", securable.Name),
+		)
+	if err != nil || permissions == nil {
+		return nil, err
+	}
+
+	columns := []string{""}
+	if len(securable.Columns) > 0 {
+		if columns, err = c.getExistingColumns(ctx, database, securable); err != nil {
+			return nil, err
+		}
+		permissions.Securable.Columns = columns
+		permissions.OtherColumnPermissions = make(map[string][]string)
+		for key := range states {
+			if key.column == "" || containsFold(columns, key.column) {
+				continue
+			}
+			name := columnNames[key.column]
+			permissions.OtherColumnPermissions[name] = append(permissions.OtherColumnPermissions[name], key.permission)
+		}
+		for _, other := range permissions.OtherColumnPermissions {
+			sort.Strings(other)
+		}
+		if len(columns) == 0 {
+			return permissions, nil
+		}
+	}
+	seen := make(map[string]struct{})
+	for key := range states {
+		if _, ok := seen[key.permission]; ok {
+			continue
+		}
+		seen[key.permission] = struct{}{}
+		// The permission is only reported if it is in the same state on every column
+		state := states[columnPermission{strings.ToLower(columns[0]), key.permission}]
+		for _, column := range columns[1:] {
+			if states[columnPermission{strings.ToLower(column), key.permission}] != state {
+				state = ""
+			}
+		}
+		switch state {
+		case "G":
+			permissions.Permissions = append(permissions.Permissions, key.permission)
+		case "W":
+			permissions.Permissions = append(permissions.Permissions, key.permission)
+			permissions.WithGrantOptionPermissions = append(permissions.WithGrantOptionPermissions, key.permission)
+		case "D":
+			permissions.DenyPermissions = append(permissions.DenyPermissions, key.permission)
+		}
+	}
+	sort.Strings(permissions.Permissions)
+	sort.Strings(permissions.WithGrantOptionPermissions)
+	sort.Strings(permissions.DenyPermissions)
+	return permissions, nil
+}
+
+// getExistingColumns returns the columns of securable.Columns that exist in the object, with their actual names.
+func (c *Connector) getExistingColumns(ctx context.Context, database string, securable model.Securable) ([]string, error) {
+	cmd := `SELECT name FROM [sys].[columns] WHERE [object_id] = OBJECT_ID(QuoteName(@schema) + '.' + QuoteName(@name))`
+	existing := make(map[string]string)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var name string
+					if err := r.Scan(&name); err != nil {
+						return err
+					}
+					existing[strings.ToLower(name)] = name
+				}
+				return nil
+			},
+			sql.Named("schema", securable.Schema),
+			sql.Named("name", securable.Name),
+		)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, 0, len(securable.Columns))
+	for _, column := range securable.Columns {
+		if name, ok := existing[strings.ToLower(column)]; ok {
+			columns = append(columns, name)
+		}
+	}
+	return columns, nil
+}
+
+// UpdateObjectPermissions applies changeType (GRANT, DENY or REVOKE) for every permission on the securable to the
+// principal, one statement per permission and column. DENY and REVOKE of a permission the principal holds WITH GRANT
+// OPTION cascade to the principals it was granted to by the principal.
+func (c *Connector) UpdateObjectPermissions(ctx context.Context, database, principalName string, securable model.Securable, permissions []string, changeType string) error {
+	cmd := securableMajorIdCmd + `IF @major_id IS NULL
+				BEGIN
+					DECLARE @msg nvarchar(max) = @type + '::' + @securable + ' does not exist'
+					;THROW 51000, @msg, 1
+				END
+			DECLARE @applies nvarchar(10) = 'TO'
+			IF @changeType = 'REVOKE' SET @applies = 'FROM'
+			DECLARE @stmt nvarchar(max) = @changeType + ' ' + @permission
+			IF @column != ''
+				BEGIN
+					SET @stmt = @stmt + ' (' + QuoteName(@column) + ')'
+				END
+			SET @stmt = @stmt + ' ON ' + @type + '::' + @securable + ' ' + @applies + ' ' + QuoteName(@principalName)
+			IF @changeType != 'GRANT' AND EXISTS (SELECT 1 FROM [sys].[database_permissions]
+				WHERE class = @class AND major_id = @major_id AND minor_id = COALESCE(COLUMNPROPERTY(@major_id, @column, 'ColumnId'), 0)
+					AND grantee_principal_id = DATABASE_PRINCIPAL_ID(@principalName) AND permission_name = @permission AND [state] = 'W')
+				BEGIN
+					SET @stmt = @stmt + ' CASCADE'
+				END
+			EXEC (@stmt)`
+	switch changeType {
+	case "GRANT", "DENY", "REVOKE":
+	default:
+		return fmt.Errorf("invalid change type %q", changeType)
+	}
+	class, ok := securableClasses[securable.Type]
+	if !ok {
+		return fmt.Errorf("invalid securable type %q", securable.Type)
+	}
+	columns := securable.Columns
+	if len(columns) == 0 {
+		columns = []string{""}
+	}
+	for _, permission := range permissions {
+		for _, column := range columns {
+			err := c.
+				setDatabase(&database).
+				ExecContext(ctx, cmd,
+					sql.Named("principalName", principalName),
+					sql.Named("permission", permission),
+					sql.Named("column", column),
+					sql.Named("changeType", changeType),
+					sql.Named("class", class),
+					sql.Named("type", securable.Type),
+					sql.Named("schema", securable.Schema),
+					sql.Named("name", securable.Name),
+				)
+			if err != nil {
+				if column != "" {
+					return errors.Wrapf(err, "unable to %s [%s] on column [%s]", changeType, permission, column)
+				}
+				return errors.Wrapf(err, "unable to %s [%s]", changeType, permission)
+			}
+		}
+	}
+	return nil
+}
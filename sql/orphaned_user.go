@@ -0,0 +1,95 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
+)
+
+// GetOrphanedUsers returns the SQL users mapped to a login whose SID has no matching server principal. Windows users
+// and groups are left out, as they usually get access through a Windows group rather than a login of their own. All
+// online user databases are checked if database is empty.
+func (c *Connector) GetOrphanedUsers(ctx context.Context, database string) ([]model.OrphanedUser, error) {
+	databases := []string{database}
+	if database == "" {
+		var err error
+		if databases, err = c.getUserDatabases(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	cmd := `SELECT dp.principal_id, dp.name, dp.type_desc, CONVERT(VARCHAR(85), dp.[sid], 1), dp.create_date
+			FROM [sys].[database_principals] dp
+			WHERE dp.type = 'S' AND dp.authentication_type_desc = 'INSTANCE'
+			AND dp.principal_id > 4 AND dp.[sid] IS NOT NULL AND dp.[sid] != 0x00
+			ORDER BY dp.name`
+	users := make([]model.OrphanedUser, 0)
+	for _, db := range databases {
+		logins, err := c.getServerPrincipalNames(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		err = c.
+			setDatabase(&db).
+			QueryContext(ctx, cmd,
+				func(r *sql.Rows) error {
+					for r.Next() {
+						user := model.OrphanedUser{Database: db}
+						if err := r.Scan(&user.PrincipalID, &user.Username, &user.TypeDesc, &user.SIDStr, &user.CreateDate); err != nil {
+							return err
+						}
+						if _, ok := logins[user.SIDStr]; !ok {
+							users = append(users, user)
+						}
+					}
+					return nil
+				},
+			)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read orphaned users of database [%s]", db)
+		}
+	}
+	return users, nil
+}
+
+func (c *Connector) getUserDatabases(ctx context.Context) ([]string, error) {
+	return c.ListDatabases(ctx, "", false)
+}
+
+// getServerPrincipalNames returns the names of the server principals by SID. The query runs in master, which sees
+// every server principal, and falls back to database for users of Azure SQL Database who cannot connect to master.
+func (c *Connector) getServerPrincipalNames(ctx context.Context, database string) (map[string]string, error) {
+	names, err := c.queryServerPrincipalNames(ctx, "master")
+	if err != nil && database != "" && database != "master" {
+		names, err = c.queryServerPrincipalNames(ctx, database)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read server principals")
+	}
+	return names, nil
+}
+
+func (c *Connector) queryServerPrincipalNames(ctx context.Context, database string) (map[string]string, error) {
+	cmd := `SELECT CONVERT(VARCHAR(85), [sid], 1), name FROM [sys].[server_principals] WHERE [sid] IS NOT NULL`
+	names := make(map[string]string)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var sid, name string
+					if err := r.Scan(&sid, &name); err != nil {
+						return err
+					}
+					names[sid] = name
+				}
+				return nil
+			},
+		)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
@@ -0,0 +1,29 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// GetBuiltinPermissions returns the permissions of sys.fn_builtin_permissions by securable class, e.g. DATABASE,
+// OBJECT or SERVER ROLE.
+func (c *Connector) GetBuiltinPermissions(ctx context.Context) (map[string][]string, error) {
+	cmd := `SELECT class_desc, permission_name FROM [sys].[fn_builtin_permissions](DEFAULT)`
+	permissions := make(map[string][]string)
+	err := c.QueryContext(ctx, cmd,
+		func(r *sql.Rows) error {
+			for r.Next() {
+				var class, permission string
+				if err := r.Scan(&class, &permission); err != nil {
+					return err
+				}
+				permissions[class] = append(permissions[class], permission)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
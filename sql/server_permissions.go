@@ -0,0 +1,127 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
+)
+
+// serverPermissionRegexp matches server permissions like "VIEW SERVER STATE" or "IMPERSONATE ON LOGIN::app".
+var serverPermissionRegexp = regexp.MustCompile(`^([A-Z]+(?: [A-Z]+)*)(?: ON (LOGIN|SERVER ROLE|ENDPOINT)::(.+))?$`)
+
+// GetServerPermissions returns the server permissions granted and denied to a login or server role. Permissions on a
+// login, server role or endpoint are returned as "PERMISSION ON CLASS::name". It returns nil if the principal does not exist.
+func (c *Connector) GetServerPermissions(ctx context.Context, principalName string) (*model.ServerPermissions, error) {
+	cmd := `SELECT pr.principal_id, COALESCE(pe.permission_name, ''), COALESCE(pe.[state], ''),
+				CASE
+					WHEN pe.class = 101 AND target.[type] = 'R' THEN 'SERVER ROLE'
+					WHEN pe.class = 101 THEN 'LOGIN'
+					WHEN pe.class = 105 THEN 'ENDPOINT'
+					ELSE ''
+				END,
+				COALESCE(target.name, ep.name, '')
+			FROM [master].[sys].[server_principals] pr
+			LEFT JOIN [master].[sys].[server_permissions] pe ON pe.grantee_principal_id = pr.principal_id
+			LEFT JOIN [master].[sys].[server_principals] target ON pe.class = 101 AND target.principal_id = pe.major_id
+			LEFT JOIN [master].[sys].[endpoints] ep ON pe.class = 105 AND ep.endpoint_id = pe.major_id
+			WHERE pr.name = @principalName
+			ORDER BY pe.permission_name`
+	var permissions *model.ServerPermissions
+	err := c.
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var principalId int
+					var permission, state, class, securable string
+					if err := r.Scan(&principalId, &permission, &state, &class, &securable); err != nil {
+						return err
+					}
+					if permissions == nil {
+						permissions = &model.ServerPermissions{
+							PrincipalName:              principalName,
+							PrincipalID:                principalId,
+							Permissions:                make([]string, 0),
+							WithGrantOptionPermissions: make([]string, 0),
+							DenyPermissions:            make([]string, 0),
+						}
+					}
+					if permission == "" {
+						continue
+					}
+					if class != "" {
+						permission = fmt.Sprintf("%s ON %s::%s", permission, class, securable)
+					}
+					switch state {
+					case "G":
+						permissions.Permissions = append(permissions.Permissions, permission)
+					case "W":
+						permissions.Permissions = append(permissions.Permissions, permission)
+						permissions.WithGrantOptionPermissions = append(permissions.WithGrantOptionPermissions, permission)
+					case "D":
+						permissions.DenyPermissions = append(permissions.DenyPermissions, permission)
+					}
+				}
+				return nil
+			},
+			sql.Named("principalName", principalName),
+		)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// UpdateServerPermissions applies changeType (GRANT, DENY or REVOKE) for every permission to the principal. DENY and
+// REVOKE of a permission the principal holds WITH GRANT OPTION cascade to the principals it was granted to by the
+// principal.
+func (c *Connector) UpdateServerPermissions(ctx context.Context, principalName string, permissions []string, changeType string) error {
+	cmd := `DECLARE @stmt nvarchar(max)
+			DECLARE @applies nvarchar(10) = 'TO'
+			IF @changeType = 'REVOKE' SET @applies = 'FROM'
+			SET @stmt = @changeType + ' ' + @permission
+			IF @class != ''
+				BEGIN
+					SET @stmt = @stmt + ' ON ' + @class + '::' + QuoteName(@securable)
+				END
+			SET @stmt = @stmt + ' ' + @applies + ' ' + QuoteName(@principalName)
+			DECLARE @major_id int = 0
+			IF @class IN ('LOGIN', 'SERVER ROLE') SELECT @major_id = principal_id FROM [sys].[server_principals] WHERE name = @securable
+			IF @class = 'ENDPOINT' SELECT @major_id = endpoint_id FROM [sys].[endpoints] WHERE name = @securable
+			IF @changeType != 'GRANT' AND EXISTS (SELECT 1 FROM [sys].[server_permissions] pe
+				INNER JOIN [sys].[server_principals] pr ON pr.principal_id = pe.grantee_principal_id
+				WHERE pr.name = @principalName AND pe.permission_name = @permission AND pe.[state] = 'W'
+					AND pe.class = CASE @class WHEN '' THEN 100 WHEN 'ENDPOINT' THEN 105 ELSE 101 END AND pe.major_id = @major_id)
+				BEGIN
+					SET @stmt = @stmt + ' CASCADE'
+				END
+			EXEC (@stmt)`
+	switch changeType {
+	case "GRANT", "DENY", "REVOKE":
+	default:
+		return fmt.Errorf("invalid change type %q", changeType)
+	}
+	database := "master"
+	for _, p := range permissions {
+		match := serverPermissionRegexp.FindStringSubmatch(p)
+		if match == nil {
+			return fmt.Errorf("invalid server permission %q", p)
+		}
+		err := c.
+			setDatabase(&database).
+			ExecContext(ctx, cmd,
+				sql.Named("principalName", principalName),
+				sql.Named("permission", match[1]),
+				sql.Named("class", match[2]),
+				sql.Named("securable", match[3]),
+				sql.Named("changeType", changeType),
+			)
+		if err != nil {
+			return errors.Wrapf(err, "unable to %s %s", changeType, p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+)
+
+// GetServerPrincipals returns the server principals whose name matches the LIKE pattern namePattern and that are members
+// of the server role roleName. Empty filters match every principal.
+func (c *Connector) GetServerPrincipals(ctx context.Context, namePattern, roleName string) ([]model.ServerPrincipal, error) {
+	cmd := `SELECT sp.principal_id, sp.name, sp.type_desc, COALESCE(CONVERT(VARCHAR(85), sp.[sid], 1), ''),
+				COALESCE(sp.default_database_name, ''), COALESCE(sp.default_language_name, ''),
+				sp.is_disabled, sp.create_date, sp.modify_date
+			FROM [master].[sys].[server_principals] sp
+			WHERE (@namePattern = '' OR sp.name LIKE @namePattern)
+			AND (@roleName = '' OR EXISTS (
+				SELECT 1 FROM [master].[sys].[server_role_members] srm
+				INNER JOIN [master].[sys].[server_principals] role ON srm.role_principal_id = role.principal_id AND role.type = 'R'
+				WHERE role.name = @roleName AND srm.member_principal_id = sp.principal_id))
+			ORDER BY sp.name`
+	var principals []model.ServerPrincipal
+	err := c.
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var principal model.ServerPrincipal
+					err := r.Scan(&principal.PrincipalID, &principal.Name, &principal.TypeDesc, &principal.SIDStr,
+						&principal.DefaultDatabase, &principal.DefaultLanguage,
+						&principal.IsDisabled, &principal.CreateDate, &principal.ModifyDate)
+					if err != nil {
+						return err
+					}
+					principals = append(principals, principal)
+				}
+				return nil
+			},
+			sql.Named("namePattern", namePattern),
+			sql.Named("roleName", roleName),
+		)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd = `SELECT srm.member_principal_id, role.name
+			FROM [master].[sys].[server_role_members] srm
+			INNER JOIN [master].[sys].[server_principals] role ON srm.role_principal_id = role.principal_id AND role.type = 'R'
+			ORDER BY role.name`
+	roles := make(map[int64][]string)
+	err = c.
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var memberId int64
+					var role string
+					if err := r.Scan(&memberId, &role); err != nil {
+						return err
+					}
+					roles[memberId] = append(roles[memberId], role)
+				}
+				return nil
+			},
+		)
+	if err != nil {
+		return nil, err
+	}
+	for i := range principals {
+		principals[i].Roles = roles[principals[i].PrincipalID]
+	}
+	return principals, nil
+}
@@ -3,9 +3,10 @@ package sql
 import (
 	"context"
 	"database/sql"
-	"strings"
+	"fmt"
 
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
 )
 
 // If managedMembers is non-empty: returns only members that are both in the role and in managedMembers.
@@ -52,69 +53,90 @@ func (c *Connector) GetServerRoleMember(ctx context.Context, roleName string, ma
 	return &model.ServerRoleMember{RoleName: roleName, Members: members}, nil
 }
 
-func (c *Connector) CreateServerRoleMember(ctx context.Context, roleName string, members []string) error {
-	cmd := `DECLARE @stmt nvarchar(max)
-			DECLARE member_cur CURSOR FOR SELECT value FROM String_Split(@members, ',')
-			DECLARE @member_name nvarchar(max)
-			OPEN member_cur
-			FETCH NEXT FROM member_cur INTO @member_name
-			WHILE @@FETCH_STATUS = 0
-				BEGIN
-					SET @stmt = 'ALTER SERVER ROLE ' + @roleName + ' ADD MEMBER ' + @member_name
-					EXEC (@stmt)
-					FETCH NEXT FROM member_cur INTO @member_name
-				END
-			CLOSE member_cur
-			DEALLOCATE member_cur
-			`
-	return c.
-		ExecContext(ctx, cmd,
-			sql.Named("roleName", roleName),
-			sql.Named("members", strings.Join(members, ",")),
+// GetServerRoleMemberships returns the direct memberships of every server role.
+func (c *Connector) GetServerRoleMemberships(ctx context.Context) ([]model.RoleMembership, error) {
+	cmd := `SELECT role.name, member.name, member.type_desc
+			FROM [sys].[server_role_members] srm
+			INNER JOIN [sys].[server_principals] role ON srm.role_principal_id = role.principal_id
+			INNER JOIN [sys].[server_principals] member ON srm.member_principal_id = member.principal_id
+			ORDER BY role.name, member.name`
+	memberships := make([]model.RoleMembership, 0)
+	err := c.
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var membership model.RoleMembership
+					if err := r.Scan(&membership.RoleName, &membership.MemberName, &membership.MemberType); err != nil {
+						return err
+					}
+					memberships = append(memberships, membership)
+				}
+				return nil
+			},
+		)
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+// GetProtectedLogins returns the name of the login the connector is connected as, the names of the other principals
+// in its login token, such as the Windows or Entra ID groups it is connected through and the server roles it holds,
+// and the name of the sa login, found by its principal_id of 1 as it may have been renamed.
+func (c *Connector) GetProtectedLogins(ctx context.Context) ([]string, error) {
+	cmd := `SELECT SUSER_SNAME()
+			UNION
+			SELECT [name] FROM [sys].[login_token]
+			UNION
+			SELECT SUSER_NAME(1) WHERE SUSER_NAME(1) IS NOT NULL`
+	logins := make([]string, 0)
+	err := c.
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var login string
+					if err := r.Scan(&login); err != nil {
+						return err
+					}
+					logins = append(logins, login)
+				}
+				return nil
+			},
 		)
+	if err != nil {
+		return nil, err
+	}
+	return logins, nil
 }
 
+func (c *Connector) CreateServerRoleMember(ctx context.Context, roleName string, members []string) error {
+	return c.UpdateServerRoleMember(ctx, roleName, members, "ADD")
+}
+
+// UpdateServerRoleMember adds (ADD) or removes (DROP) every member to or from the role, one statement per member.
 func (c *Connector) UpdateServerRoleMember(ctx context.Context, roleName string, members []string, changeType string) error {
 	cmd := `DECLARE @stmt nvarchar(max)
-			DECLARE member_cur CURSOR FOR SELECT value FROM String_Split(@members, ',')
-			DECLARE @member_name nvarchar(max)
-			OPEN member_cur
-			FETCH NEXT FROM member_cur INTO @member_name
-			WHILE @@FETCH_STATUS = 0
-				BEGIN
-					SET @stmt = 'ALTER SERVER ROLE ' + @roleName + ' ' + @changeType + ' MEMBER ' + @member_name
-					EXEC (@stmt)
-					FETCH NEXT FROM member_cur INTO @member_name
-				END
-			CLOSE member_cur
-			DEALLOCATE member_cur
-			`
-	return c.
-		ExecContext(ctx, cmd,
-			sql.Named("roleName", roleName),
-			sql.Named("members", strings.Join(members, ",")),
-			sql.Named("changeType", changeType),
-		)
+			SET @stmt = 'ALTER SERVER ROLE ' + QuoteName(@roleName) + ' ' + @changeType + ' MEMBER ' + QuoteName(@member)
+			EXEC (@stmt)`
+	switch changeType {
+	case "ADD", "DROP":
+	default:
+		return fmt.Errorf("invalid change type %q", changeType)
+	}
+	for _, member := range members {
+		err := c.
+			ExecContext(ctx, cmd,
+				sql.Named("roleName", roleName),
+				sql.Named("member", member),
+				sql.Named("changeType", changeType),
+			)
+		if err != nil {
+			return errors.Wrapf(err, "unable to %s member [%s]", changeType, member)
+		}
+	}
+	return nil
 }
 
 func (c *Connector) DeleteServerRoleMember(ctx context.Context, roleName string, members []string) error {
-	cmd := `DECLARE @stmt nvarchar(max)
-			DECLARE member_cur CURSOR FOR SELECT value FROM String_Split(@members, ',')
-			DECLARE @member_name nvarchar(max)
-			OPEN member_cur
-			FETCH NEXT FROM member_cur INTO @member_name
-			WHILE @@FETCH_STATUS = 0
-				BEGIN
-					SET @stmt = 'ALTER SERVER ROLE ' + @roleName + ' DROP MEMBER ' + @member_name
-					EXEC (@stmt)
-					FETCH NEXT FROM member_cur INTO @member_name
-				END
-			CLOSE member_cur
-			DEALLOCATE member_cur
-			`
-	return c.
-		ExecContext(ctx, cmd,
-			sql.Named("roleName", roleName),
-			sql.Named("members", strings.Join(members, ",")),
-		)
+	return c.UpdateServerRoleMember(ctx, roleName, members, "DROP")
 }
@@ -26,7 +26,10 @@ func GetFactory() model.ConnectorFactory {
 	return new(factory)
 }
 
-func (f factory) GetConnector(prefix string, data *schema.ResourceData) (interface{}, error) {
+// planTimeout is the connection timeout of connectors created at plan time, when there are no resource timeouts.
+const planTimeout = 30 * time.Second
+
+func (f factory) GetConnector(prefix string, data model.ResourceData) (interface{}, error) {
 	if len(prefix) > 0 {
 		prefix = prefix + ".0."
 	}
@@ -34,7 +37,10 @@ func (f factory) GetConnector(prefix string, data *schema.ResourceData) (interfa
 	connector := &Connector{
 		Host:    data.Get(prefix + "host").(string),
 		Port:    data.Get(prefix + "port").(string),
-		Timeout: data.Timeout(schema.TimeoutRead),
+		Timeout: planTimeout,
+	}
+	if resourceData, ok := data.(*schema.ResourceData); ok {
+		connector.Timeout = resourceData.Timeout(schema.TimeoutRead)
 	}
 
 	if admin, ok := data.GetOk(prefix + "login.0"); ok {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ValeruS/terraform-provider-mssql/mssql/model"
+	"github.com/pkg/errors"
 )
 
 func (c *Connector) GetUser(ctx context.Context, database, username string) (*model.User, error) {
@@ -71,7 +72,8 @@ func (c *Connector) GetUser(ctx context.Context, database, username string) (*mo
 			},
 			sql.Named("sid", sid),
 		)
-		if err != nil {
+		// An orphaned user has no login left, and reads back with an empty login name
+		if err != nil && err != sql.ErrNoRows {
 			return nil, err
 		}
 	}
@@ -88,6 +90,32 @@ func (c *Connector) GetUser(ctx context.Context, database, username string) (*mo
 			return nil, err
 		}
 	}
+	switch user.TypeStr {
+	case "S":
+		user.WithoutLogin = user.AuthType == "NONE"
+	case "C", "K":
+		cmd = "SELECT name FROM [sys].[certificates] WHERE sid = @sid"
+		if user.TypeStr == "K" {
+			cmd = "SELECT name FROM [sys].[asymmetric_keys] WHERE sid = @sid"
+		}
+		var name string
+		err = c.
+			setDatabase(&database).
+			QueryRowContext(ctx, cmd,
+				func(r *sql.Row) error {
+					return r.Scan(&name)
+				},
+				sql.Named("sid", sid),
+			)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		if user.TypeStr == "C" {
+			user.CertificateName = name
+		} else {
+			user.AsymmetricKeyName = name
+		}
+	}
 	if roles == "" {
 		user.Roles = make([]string, 0)
 	} else {
@@ -115,7 +143,19 @@ func (c *Connector) CreateUser(ctx context.Context, database string, user *model
 							SET @stmt = @stmt + ', DEFAULT_LANGUAGE = ' + Coalesce(QuoteName(@language), 'NONE')
 						END
 				END
-			IF @loginName = '' AND @username != '' AND @password = ''
+			IF @withoutLogin = 1
+				BEGIN
+					SET @stmt = 'CREATE USER ' + QuoteName(@username) + ' WITHOUT LOGIN WITH DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)
+				END
+			IF @certificateName != ''
+				BEGIN
+					SET @stmt = 'CREATE USER ' + QuoteName(@username) + ' FOR CERTIFICATE ' + QuoteName(@certificateName)
+				END
+			IF @asymmetricKeyName != ''
+				BEGIN
+					SET @stmt = 'CREATE USER ' + QuoteName(@username) + ' FOR ASYMMETRIC KEY ' + QuoteName(@asymmetricKeyName)
+				END
+			IF @loginName = '' AND @username != '' AND @password = '' AND @withoutLogin = 0 AND @certificateName = '' AND @asymmetricKeyName = ''
 				BEGIN
 					IF @@VERSION LIKE 'Microsoft SQL Azure%'
 						BEGIN
@@ -135,150 +175,163 @@ func (c *Connector) CreateUser(ctx context.Context, database string, user *model
 										'DEFAULT_LANGUAGE = ' + Coalesce(QuoteName(@language), 'NONE')
 						END
 				END
-
-			BEGIN TRANSACTION;
-			EXEC sp_getapplock @Resource = 'create_func', @LockMode = 'Exclusive';
-			IF exists (select compatibility_level FROM sys.databases where name = db_name() and compatibility_level < 130) AND objectproperty(object_id('String_Split'), 'isProcedure') IS NULL
-			BEGIN
-					DECLARE @sql NVARCHAR(MAX);
-					SET @sql = N'Create FUNCTION [dbo].[String_Split]
-								(
-										@string    nvarchar(max),
-										@delimiter nvarchar(max)
-								)
-								/*
-										The same as STRING_SPLIT for compatibility level < 130
-										https://docs.microsoft.com/en-us/sql/t-sql/functions/string-split-transact-sql?view=sql-server-ver15
-								*/
-								RETURNS TABLE AS RETURN
-								(
-										SELECT
-											--ROW_NUMBER ( ) over(order by (select 0))                            AS id     --  intuitive, but not correect
-												Split.a.value(''let $n := . return count(../*[. << $n]) + 1'', ''int'') AS id
-											, Split.a.value(''.'', ''NVARCHAR(MAX)'')                                 AS value
-										FROM
-										(
-												SELECT CAST(''<X>''+REPLACE(@string, @delimiter, ''</X><X>'')+''</X>'' AS XML) AS String
-										) AS a
-										CROSS APPLY String.nodes(''/X'') AS Split(a)
-								)';
-					EXEC sp_executesql @sql;
-			END
-			EXEC sp_releaseapplock @Resource = 'create_func';
-			COMMIT TRANSACTION;
-			SET @stmt = @stmt + '; ' +
-									'DECLARE role_cur CURSOR FOR SELECT name FROM ' + QuoteName(@database) + '.[sys].[database_principals] WHERE type = ''R'' AND name != ''public'' AND name COLLATE SQL_Latin1_General_CP1_CI_AS IN (SELECT value FROM String_Split(' + QuoteName(@roles, '''') + ', '',''));' +
-									'DECLARE @role nvarchar(max);' +
-									'OPEN role_cur;' +
-									'FETCH NEXT FROM role_cur INTO @role;' +
-									'WHILE @@FETCH_STATUS = 0' +
-									'  BEGIN' +
-									'    DECLARE @sql nvarchar(max);' +
-									'    SET @sql = ''ALTER ROLE '' + QuoteName(@role) + '' ADD MEMBER ' + QuoteName(@username) + ''';' +
-									'    EXEC (@sql);' +
-									'    FETCH NEXT FROM role_cur INTO @role;' +
-									'  END;' +
-									'CLOSE role_cur;' +
-									'DEALLOCATE role_cur;'
 			EXEC (@stmt)`
-	return c.
+	err := c.
 		setDatabase(&database).
 		ExecContext(ctx, cmd,
-			sql.Named("database", database),
 			sql.Named("username", user.Username),
 			sql.Named("objectId", user.ObjectId),
 			sql.Named("loginName", user.LoginName),
 			sql.Named("password", user.Password),
+			sql.Named("withoutLogin", user.WithoutLogin),
+			sql.Named("certificateName", user.CertificateName),
+			sql.Named("asymmetricKeyName", user.AsymmetricKeyName),
 			sql.Named("authType", user.AuthType),
 			sql.Named("typeStr", user.TypeStr),
 			sql.Named("defaultSchema", user.DefaultSchema),
 			sql.Named("defaultLanguage", user.DefaultLanguage),
-			sql.Named("roles", strings.Join(user.Roles, ",")),
 		)
+	if err != nil {
+		return err
+	}
+	return c.updateUserRoles(ctx, database, user.Username, user.Roles)
 }
 
+// UpdateUser alters the user. The user is renamed from PreviousUsername to Username if PreviousUsername is set, and
+// mapped to LoginName if LoginName is set.
 func (c *Connector) UpdateUser(ctx context.Context, database string, user *model.User) error {
 	cmd := `DECLARE @stmt nvarchar(max)
-			SET @stmt = 'ALTER USER ' + QuoteName(@username) + ' '
+			DECLARE @current_name nvarchar(max) = @username
+			IF @previousUsername != '' SET @current_name = @previousUsername
+			DECLARE @options nvarchar(max) = ''
 			DECLARE @language nvarchar(max) = @defaultLanguage
 			IF @language = '' SET @language = NULL
-			SET @stmt = @stmt + 'WITH DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)
+			DECLARE @auth_type nvarchar(max)
+			DECLARE @type nvarchar(max)
+			SELECT @auth_type = authentication_type_desc, @type = type FROM [sys].[database_principals] WHERE name = @current_name
+			-- Users mapped to a certificate or an asymmetric key have no default schema
+			IF @type NOT IN ('C', 'K')
+				BEGIN
+					SET @options = @options + ', DEFAULT_SCHEMA = ' + QuoteName(@defaultSchema)
+				END
+			IF @previousUsername != ''
+				BEGIN
+					SET @options = @options + ', NAME = ' + QuoteName(@username)
+				END
+			IF @loginName != ''
+				BEGIN
+					SET @options = @options + ', LOGIN = ' + QuoteName(@loginName)
+				END
 			IF @password != ''
 				BEGIN
-					SET @stmt = @stmt + ', PASSWORD = ' + QuoteName(@password, '''')
+					SET @options = @options + ', PASSWORD = ' + QuoteName(@password, '''')
 				END
-			DECLARE @auth_type nvarchar(max) = (SELECT authentication_type_desc FROM [sys].[database_principals] WHERE name = @username)
-			IF NOT @@VERSION LIKE 'Microsoft SQL Azure%' AND @auth_type != 'INSTANCE'
+			IF NOT @@VERSION LIKE 'Microsoft SQL Azure%' AND @auth_type NOT IN ('INSTANCE', 'NONE')
 				BEGIN
-					SET @stmt = @stmt + ', DEFAULT_LANGUAGE = ' + Coalesce(QuoteName(@language), 'NONE')
+					SET @options = @options + ', DEFAULT_LANGUAGE = ' + Coalesce(QuoteName(@language), 'NONE')
 				END
-
-			BEGIN TRANSACTION;
-			EXEC sp_getapplock @Resource = 'create_func', @LockMode = 'Exclusive';
-			IF exists (select compatibility_level FROM sys.databases where name = db_name() and compatibility_level < 130) AND objectproperty(object_id('String_Split'), 'isProcedure') IS NULL
-			BEGIN
-					DECLARE @sql NVARCHAR(MAX);
-					SET @sql = N'Create FUNCTION [dbo].[String_Split]
-								(
-										@string    nvarchar(max),
-										@delimiter nvarchar(max)
-								)
-								/*
-										The same as STRING_SPLIT for compatibility level < 130
-										https://docs.microsoft.com/en-us/sql/t-sql/functions/string-split-transact-sql?view=sql-server-ver15
-								*/
-								RETURNS TABLE AS RETURN
-								(
-										SELECT
-											--ROW_NUMBER ( ) over(order by (select 0))                            AS id     --  intuitive, but not correect
-												Split.a.value(''let $n := . return count(../*[. << $n]) + 1'', ''int'') AS id
-											, Split.a.value(''.'', ''NVARCHAR(MAX)'')                                 AS value
-										FROM
-										(
-												SELECT CAST(''<X>''+REPLACE(@string, @delimiter, ''</X><X>'')+''</X>'' AS XML) AS String
-										) AS a
-										CROSS APPLY String.nodes(''/X'') AS Split(a)
-								)';
-					EXEC sp_executesql @sql;
-			END
-			EXEC sp_releaseapplock @Resource = 'create_func';
-			COMMIT TRANSACTION;
-			SET @stmt = @stmt + '; ' +
-									'DECLARE @sql nvarchar(max);' +
-									'DECLARE @role nvarchar(max);' +
-									'DECLARE del_role_cur CURSOR FOR SELECT name FROM ' + QuoteName(@database) + '.[sys].[database_principals] WHERE type = ''R'' AND name != ''public'' AND name IN (SELECT name FROM ' + QuoteName(@database) + '.[sys].[database_role_members] drm, ' + QuoteName(@database) + '.[sys].[database_principals] db WHERE drm.member_principal_id = DATABASE_PRINCIPAL_ID(' + QuoteName(@username, '''') + ') AND drm.role_principal_id = db.principal_id) AND name COLLATE SQL_Latin1_General_CP1_CI_AS NOT IN(SELECT value FROM String_Split(' + QuoteName(@roles, '''') + ', '',''));' +
-									'DECLARE add_role_cur CURSOR FOR SELECT name FROM ' + QuoteName(@database) + '.[sys].[database_principals] WHERE type = ''R'' AND name != ''public'' AND name NOT IN (SELECT name FROM ' + QuoteName(@database) + '.[sys].[database_role_members] drm, ' + QuoteName(@database) + '.[sys].[database_principals] db WHERE drm.member_principal_id = DATABASE_PRINCIPAL_ID(' + QuoteName(@username, '''') + ') AND drm.role_principal_id = db.principal_id) AND name COLLATE SQL_Latin1_General_CP1_CI_AS IN(SELECT value FROM String_Split(' + QuoteName(@roles, '''') + ', '',''));' +
-									'OPEN del_role_cur;' +
-									'FETCH NEXT FROM del_role_cur INTO @role;' +
-									'WHILE @@FETCH_STATUS = 0' +
-									'  BEGIN' +
-									'    SET @sql = ''ALTER ROLE '' + QuoteName(@role) + '' DROP MEMBER ' + QuoteName(@username) + ''';' +
-									'    EXEC (@sql);' +
-									'    FETCH NEXT FROM del_role_cur INTO @role;' +
-									'  END;' +
-									'CLOSE del_role_cur;' +
-									'DEALLOCATE del_role_cur;' +
-									'OPEN add_role_cur;' +
-									'FETCH NEXT FROM add_role_cur INTO @role;' +
-									'WHILE @@FETCH_STATUS = 0' +
-									'  BEGIN' +
-									'    SET @sql = ''ALTER ROLE '' + QuoteName(@role) + '' ADD MEMBER ' + QuoteName(@username) + ''';' +
-									'    EXEC (@sql);' +
-									'    FETCH NEXT FROM add_role_cur INTO @role;' +
-									'  END;' +
-									'CLOSE add_role_cur;' +
-									'DEALLOCATE add_role_cur;'
-			EXEC (@stmt)`
-	return c.
+			SET @stmt = ''
+			IF @options != ''
+				BEGIN
+					SET @stmt = 'ALTER USER ' + QuoteName(@current_name) + ' WITH ' + STUFF(@options, 1, 2, '')
+				END
+			IF @stmt != '' EXEC (@stmt)`
+	err := c.
 		setDatabase(&database).
 		ExecContext(ctx, cmd,
-			sql.Named("database", database),
 			sql.Named("username", user.Username),
+			sql.Named("previousUsername", user.PreviousUsername),
+			sql.Named("loginName", user.LoginName),
 			sql.Named("password", user.Password),
 			sql.Named("defaultSchema", user.DefaultSchema),
 			sql.Named("defaultLanguage", user.DefaultLanguage),
-			sql.Named("roles", strings.Join(user.Roles, ",")),
 		)
+	if err != nil {
+		return err
+	}
+	return c.updateUserRoles(ctx, database, user.Username, user.Roles)
+}
+
+// updateUserRoles makes the user a member of exactly the given database roles. Roles are matched case-insensitively,
+// unknown roles and public are ignored. Memberships are left untouched if roles is nil.
+func (c *Connector) updateUserRoles(ctx context.Context, database, username string, roles []string) error {
+	if roles == nil {
+		return nil
+	}
+	cmd := `SELECT r.name, CAST(CASE WHEN drm.member_principal_id IS NULL THEN 0 ELSE 1 END AS bit)
+			FROM [sys].[database_principals] r
+			LEFT JOIN [sys].[database_role_members] drm ON drm.role_principal_id = r.principal_id AND drm.member_principal_id = DATABASE_PRINCIPAL_ID(@username)
+			WHERE r.type = 'R' AND r.name != 'public'`
+	wanted := make(map[string]struct{}, len(roles))
+	for _, role := range roles {
+		wanted[strings.ToLower(role)] = struct{}{}
+	}
+	var toAdd, toDrop []string
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var name string
+					var isMember bool
+					if err := r.Scan(&name, &isMember); err != nil {
+						return err
+					}
+					_, ok := wanted[strings.ToLower(name)]
+					if ok && !isMember {
+						toAdd = append(toAdd, name)
+					}
+					if !ok && isMember {
+						toDrop = append(toDrop, name)
+					}
+				}
+				return nil
+			},
+			sql.Named("username", username),
+		)
+	if err != nil {
+		return err
+	}
+	for _, role := range toDrop {
+		if err = c.UpdateDatabaseRoleMember(ctx, database, role, []string{username}, "DROP"); err != nil {
+			return errors.Wrapf(err, "unable to remove user [%s] from role [%s]", username, role)
+		}
+	}
+	for _, role := range toAdd {
+		if err = c.UpdateDatabaseRoleMember(ctx, database, role, []string{username}, "ADD"); err != nil {
+			return errors.Wrapf(err, "unable to add user [%s] to role [%s]", username, role)
+		}
+	}
+	return nil
+}
+
+// GetUserDirectRoles returns the database roles the user is a direct member of, without the roles inherited through
+// nested role memberships.
+func (c *Connector) GetUserDirectRoles(ctx context.Context, database, username string) ([]string, error) {
+	cmd := `SELECT r.name
+			FROM [sys].[database_role_members] drm
+			INNER JOIN [sys].[database_principals] r ON r.principal_id = drm.role_principal_id
+			WHERE drm.member_principal_id = DATABASE_PRINCIPAL_ID(@username)`
+	roles := make([]string, 0)
+	err := c.
+		setDatabase(&database).
+		QueryContext(ctx, cmd,
+			func(r *sql.Rows) error {
+				for r.Next() {
+					var name string
+					if err := r.Scan(&name); err != nil {
+						return err
+					}
+					roles = append(roles, name)
+				}
+				return nil
+			},
+			sql.Named("username", username),
+		)
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
 }
 
 func (c *Connector) DeleteUser(ctx context.Context, database, username string) error {
@@ -329,3 +382,18 @@ func (c *Connector) DeleteUser(ctx context.Context, database, username string) e
 			sql.Named("username", username),
 		)
 }
+
+// DropStringSplitHelper drops the [dbo].[String_Split] function created by earlier provider versions. The function is
+// only dropped if its definition matches the one created by the provider and no other object references it.
+func (c *Connector) DropStringSplitHelper(ctx context.Context, database string) error {
+	cmd := `DECLARE @object_id int = OBJECT_ID('[dbo].[String_Split]', 'IF')
+			IF @object_id IS NOT NULL
+				AND OBJECT_DEFINITION(@object_id) LIKE '%The same as STRING_SPLIT for compatibility level < 130%'
+				AND NOT EXISTS (SELECT 1 FROM [sys].[sql_expression_dependencies] WHERE referenced_id = @object_id)
+				BEGIN
+					DROP FUNCTION [dbo].[String_Split]
+				END`
+	return c.
+		setDatabase(&database).
+		ExecContext(ctx, cmd)
+}